@@ -31,6 +31,14 @@ func BenchmarkEncoding(b *testing.B) {
 			}
 		})
 
+		b.Run("AppendPolorize", func(b *testing.B) {
+			buffer := make([]byte, 0, len(wire))
+
+			for i := 0; i < b.N; i++ {
+				buffer, _ = AppendPolorize(buffer[:0], object)
+			}
+		})
+
 		b.Run("Depolorize", func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
 				_ = Depolorize(newObject, wire)
@@ -119,3 +127,16 @@ func BenchmarkDocument(b *testing.B) {
 		})
 	})
 }
+
+func BenchmarkMapEncoding(b *testing.B) {
+	object := make(map[uint64]string, 100000)
+	for i := uint64(0); i < 100000; i++ {
+		object[i*7919] = "value"
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = Polorize(object)
+	}
+}
@@ -3,7 +3,6 @@ package polo
 import (
 	"bytes"
 	"errors"
-	"io"
 )
 
 // packbuffer is a read-only buffer that is obtained from a compound wire (pack).
@@ -17,6 +16,9 @@ type packbuffer struct {
 
 	cw WireType // represents the wiretype of the current element
 	nw WireType // represents the wiretype of the next element
+
+	size int // represents the total number of elements in the load
+	tags int // represents the number of element tags consumed from the head
 }
 
 // newpackbuffer creates a new packbuffer for a given head and body slice of bytes.
@@ -29,6 +31,14 @@ func newpackbuffer(head, body []byte) *packbuffer {
 	lr.head = bytes.NewReader(head)
 	lr.body = body
 
+	// Count the number of elements from the number of varint tags in the head.
+	// Every varint is terminated by a byte that does not have its MSB set.
+	for _, b := range head {
+		if b < 0x80 {
+			lr.size++
+		}
+	}
+
 	// Seed the offset values of the packbuffer by iterating once
 	_, _ = lr.next()
 
@@ -41,6 +51,16 @@ func (lr *packbuffer) done() bool {
 	return lr.noff == -1
 }
 
+// remaining returns the number of elements in the packbuffer that have not been read.
+func (lr *packbuffer) remaining() int {
+	if lr.done() {
+		return 0
+	}
+
+	// The tag for the next element has already been consumed
+	return lr.size - lr.tags + 1
+}
+
 // peek returns the WireType of the next element along with a boolean.
 // Returns (WireNull, false) if there are no elements left in the packbuffer.
 func (lr *packbuffer) peek() (WireType, bool) {
@@ -67,7 +87,7 @@ func (lr *packbuffer) next() (readbuffer, error) {
 		lr.noff, lr.nw = -1, WireNull
 
 		// Create a readbuffer from the current wiretype and the rest of data in the body and return it
-		return readbuffer{lr.cw, lr.body[lr.coff:]}, nil
+		return readbuffer{wire: lr.cw, data: lr.body[lr.coff:]}, nil
 	}
 
 	// Attempt to consume a varint from the head reader
@@ -76,40 +96,24 @@ func (lr *packbuffer) next() (readbuffer, error) {
 		return readbuffer{}, MalformedTagError{err.Error()}
 	}
 
+	// Increment the number of consumed tags
+	lr.tags++
+
 	// Update the current values from the next values
 	lr.coff, lr.cw = lr.noff, lr.nw
 	// Set the next values based on the tag data (first 4 bits represent wiretype, rest the offset position of the dats)
 	lr.noff, lr.nw = int(tag>>4), WireType(tag&15)
 
 	// Create a readbuffer from the current wiretype and body bytes between the two offset positions
-	return readbuffer{lr.cw, lr.body[lr.coff:lr.noff]}, nil
+	return readbuffer{wire: lr.cw, data: lr.body[lr.coff:lr.noff]}, nil
 }
 
-// read consumes n number of bytes from the reader and returns it as a slice of bytes.
-// Throws an error if the r does not have n number of bytes.
-func read(r io.Reader, n int) ([]byte, error) {
-	// If n == 0, return an empty byte slice
-	if n == 0 {
-		return []byte{}, nil
-	}
-
-	// Create a slice of bytes with the specified length
-	d := make([]byte, n)
-
-	// Read from the reader into d
-	if rn, err := r.Read(d); err != nil || rn != n {
-		return nil, errors.New("insufficient data in reader")
+// split slices the first n bytes from b and returns it along with the rest of b.
+// The returned slices alias b and no data is copied. Throws an error if b does not have n number of bytes.
+func split(b []byte, n int) ([]byte, []byte, error) {
+	if n < 0 || n > len(b) {
+		return nil, nil, errors.New("insufficient data in reader")
 	}
 
-	return d, nil
-}
-
-// prepend is a generic function that accepts an object of some any type and a slice of objects of
-// the same type and inserts the object to the front of the slice and shifts the other elements.
-func prepend[Element any](y Element, x []Element) []Element {
-	x = append(x, *new(Element))
-	copy(x[1:], x)
-	x[0] = y
-
-	return x
+	return b[:n], b[n:], nil
 }
@@ -49,7 +49,7 @@ func TestLoadReader(t *testing.T) {
 
 	data, err := load.next()
 	assert.Nil(t, err)
-	assert.Equal(t, readbuffer{WireWord, []byte{112, 98, 98}}, data)
+	assert.Equal(t, readbuffer{WireWord, []byte{112, 98, 98}, nil}, data)
 
 	peek, ok := load.peek()
 	assert.True(t, ok)
@@ -58,7 +58,7 @@ func TestLoadReader(t *testing.T) {
 
 	data, err = load.next()
 	assert.Nil(t, err)
-	assert.Equal(t, readbuffer{WirePosInt, []byte{1, 44}}, data)
+	assert.Equal(t, readbuffer{WirePosInt, []byte{1, 44}, nil}, data)
 
 	peek, ok = load.peek()
 	assert.True(t, ok)
@@ -67,7 +67,7 @@ func TestLoadReader(t *testing.T) {
 
 	data, err = load.next()
 	assert.Nil(t, err)
-	assert.Equal(t, readbuffer{WireWord, []byte{112, 98, 98}}, data)
+	assert.Equal(t, readbuffer{WireWord, []byte{112, 98, 98}, nil}, data)
 
 	peek, ok = load.peek()
 	assert.False(t, ok)
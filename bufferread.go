@@ -3,16 +3,19 @@ package polo
 import (
 	"bytes"
 	"encoding/binary"
-	"errors"
-	"fmt"
 	"math"
 	"math/big"
+	"unsafe"
 )
 
 // readbuffer is a read-only buffer that is obtained from a single tag and its body.
 type readbuffer struct {
 	wire WireType
 	data []byte
+
+	// tagged is the element with its tag, if the tag is
+	// a single byte that is contiguous with the data
+	tagged []byte
 }
 
 // newreadbuffer creates a new readbuffer from a given slice of bytes b.
@@ -29,69 +32,81 @@ func newreadbuffer(b []byte) (readbuffer, error) {
 	}
 
 	// Create a readbuffer from the wiretype of the tag (first 4 bits)
-	return readbuffer{WireType(tag & 15), b[consumed:]}, nil
+	rb := readbuffer{wire: WireType(tag & 15), data: b[consumed:]}
+	// A single byte tag with no offset is identical to the
+	// wiretype and can be aliased along with the data
+	if consumed == 1 && tag == uint64(rb.wire) {
+		rb.tagged = b
+	}
+
+	return rb, nil
 }
 
 // bytes returns the full readbuffer as slice of bytes.
-// It prepends its wiretype to rest of the data.
+// It prepends its wiretype to a copy of the rest of the data.
 func (rb readbuffer) bytes() []byte {
-	rbytes := make([]byte, len(rb.data))
-	copy(rbytes, rb.data)
+	rbytes := make([]byte, len(rb.data)+1)
+	rbytes[0] = byte(rb.wire)
+	copy(rbytes[1:], rb.data)
 
-	return prepend(byte(rb.wire), rbytes)
+	return rbytes
 }
 
 // unpack returns a packbuffer from a readbuffer.
+// The head and body of the packbuffer are sliced from the readbuffer data without copying it.
 // Throws an error if the wiretype of the readbuffer is not compound (pack)
 func (rb *readbuffer) unpack() (*packbuffer, error) {
 	// Check that readbuffer has a compound wiretype
 	if !rb.wire.IsCompound() {
-		return nil, errors.New("load convert fail: not a compound wire")
+		return nil, MalformedLoadError{msg: "not a compound wire"}
 	}
 
-	// Create a reader from the readbuffer data
-	r := bytes.NewReader(rb.data)
-
-	// Attempt to consume a varint from the reader for the load tag
-	loadtag, _, err := consumeVarint(r)
+	// Attempt to consume a varint from the readbuffer data for the load tag
+	loadtag, consumed, err := consumeVarint(bytes.NewReader(rb.data))
 	if err != nil {
-		return nil, fmt.Errorf("load convert fail: %w", MalformedTagError{err.Error()})
+		return nil, MalformedLoadError{err: MalformedTagError{err.Error()}}
 	}
 
 	// Check that the tag has a type of WireLoad
 	if loadtag&15 != uint64(WireLoad) {
-		return nil, errors.New("load convert fail: missing load tag")
+		return nil, MalformedLoadError{msg: "missing load tag"}
 	}
 
-	// Read the number of bytes specified by the load for the header
-	head, err := read(r, int(loadtag>>4))
+	// Slice the number of bytes specified by the load for the head,
+	// the remaining bytes in the readbuffer data are the body
+	head, body, err := split(rb.data[consumed:], int(loadtag>>4))
 	if err != nil {
-		return nil, fmt.Errorf("load convert fail: missing head: %w", err)
+		return nil, MalformedLoadError{msg: "missing head", err: err}
 	}
 
-	// Read the remaining bytes in the reader for the body
-	body, _ := read(r, r.Len())
-
 	// Create a new packbuffer and return it
 	lr := newpackbuffer(head, body)
 
 	return lr, nil
 }
 
-func (rb readbuffer) asAny() Any {
+func (rb readbuffer) asAny(zeroCopy bool) Any {
 	if rb.wire == WireNull {
 		return Any{0}
 	}
 
+	if zeroCopy && rb.tagged != nil {
+		return rb.tagged
+	}
+
 	return rb.bytes()
 }
 
-func (rb readbuffer) asRaw() (Raw, error) {
+func (rb readbuffer) asRaw(zeroCopy bool) (Raw, error) {
 	if rb.wire != WireRaw {
 		return nil, IncompatibleWireType(rb.wire, WireRaw)
 	}
 
-	return rb.data, nil
+	if zeroCopy {
+		return rb.data, nil
+	}
+
+	return bytes.Clone(rb.data), nil
 }
 
 func (rb readbuffer) decodeBool() (bool, error) {
@@ -110,10 +125,14 @@ func (rb readbuffer) decodeBool() (bool, error) {
 	}
 }
 
-func (rb readbuffer) decodeBytes(allowPack bool) ([]byte, error) {
+func (rb readbuffer) decodeBytes(allowPack, zeroCopy bool) ([]byte, error) {
 	switch rb.wire {
 	case WireWord:
-		return rb.data, nil
+		if zeroCopy {
+			return rb.data, nil
+		}
+
+		return bytes.Clone(rb.data), nil
 
 	// Packed Bytes Value ([]uint8)
 	case WirePack:
@@ -159,7 +178,8 @@ func (rb readbuffer) decodeBytes(allowPack bool) ([]byte, error) {
 }
 
 func (rb readbuffer) decodeBytes32(allowPack bool) ([32]byte, error) {
-	value, err := rb.decodeBytes(allowPack)
+	// The value is copied into the array, so it does not need to be copied from the data
+	value, err := rb.decodeBytes(allowPack, true)
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -175,10 +195,15 @@ func (rb readbuffer) decodeBytes32(allowPack bool) ([32]byte, error) {
 	return bytes32, nil
 }
 
-func (rb readbuffer) decodeString() (string, error) {
+func (rb readbuffer) decodeString(zeroCopy bool) (string, error) {
 	switch rb.wire {
 	// Convert []byte to string
 	case WireWord:
+		// Alias the data as the string, avoiding the copy of a conversion
+		if zeroCopy && len(rb.data) != 0 {
+			return unsafe.String(unsafe.SliceData(rb.data), len(rb.data)), nil
+		}
+
 		return string(rb.data), nil
 	// Empty String (Default)
 	case WireNull:
@@ -267,8 +292,9 @@ func (rb readbuffer) decodeInt64() (decoded int64, err error) {
 		// Decode the data into a uint64
 		number := binary.BigEndian.Uint64(append(make([]byte, 8-len(rb.data), 8), rb.data...))
 
-		// Check that number is within bounds for int64
-		if number > math.MaxInt64 {
+		// Check that number is within bounds for int64.
+		// The magnitude of a negative number can be one larger (for math.MinInt64)
+		if number > math.MaxInt64 && (rb.wire != WireNegInt || number != math.MaxInt64+1) {
 			return 0, IncompatibleValueError{"overflow for signed integer"}
 		}
 
@@ -385,11 +411,11 @@ func (rb readbuffer) decodeBigInt() (*big.Int, error) {
 	}
 }
 
-func (rb readbuffer) decodeDocument() (Document, error) {
+func (rb readbuffer) decodeDocument(config *wireConfig) (Document, error) {
 	switch rb.wire {
 	case WireDoc:
 		// Get the next element as a pack depolorizer with the slice elements
-		pack, err := newLoadDepolorizer(rb, nil)
+		pack, err := newLoadDepolorizer(rb, config)
 		if err != nil {
 			return nil, err
 		}
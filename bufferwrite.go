@@ -1,17 +1,72 @@
 package polo
 
+import "slices"
+
 // writebuffer is a write-only byte buffer that appends to a head and body
 // buffer simultaneously. It can be instantiated without a constructor
+//
+// A writebuffer can also write in place into a shared output (see reserve), in which case
+// its body is appended directly to the output and its tags are written into a head of known
+// size that is reserved in the output before the body. The head and body buffers are unused.
 type writebuffer struct {
 	head, body      []byte
 	offset, counter uint64
+
+	// out is the output that the writebuffer writes into in place, if any.
+	// hpos and hend are the positions of the next tag and the end of the reserved head in out.
+	// A head that is overfilled or underfilled is marked with a hpos beyond hend (see filled).
+	out        *[]byte
+	hpos, hend int
+}
+
+// reserve sets the writebuffer to write in place into out, with a head of the given size reserved
+// at the end of out. Any contents of the writebuffer are cleared, but it retains its capacity.
+func (wb *writebuffer) reserve(out *[]byte, head int) {
+	wb.reset()
+
+	wb.out, wb.hpos = out, len(*out)
+	*out = append(*out, make([]byte, head)...)
+	wb.hend = len(*out)
+}
+
+// reserveLoad sets the writebuffer to write in place into out as a load, i.e, a load key
+// for a head of the given size is appended to out, after which the head is reserved.
+func (wb *writebuffer) reserveLoad(out *[]byte, head int) {
+	*out = appendVarint(*out, (uint64(head)<<4)|uint64(WireLoad))
+	wb.reserve(out, head)
+}
+
+// filled returns whether the head of a writebuffer that writes in place has been exactly filled with tags.
+func (wb writebuffer) filled() bool {
+	return wb.hpos == wb.hend
+}
+
+// writeTag appends a varint tag to the head of the writebuffer, or writes it into the reserved head
+// if the writebuffer writes in place. A tag that does not fit in the reserved head is not written.
+func (wb *writebuffer) writeTag(tag uint64) {
+	if wb.out == nil {
+		wb.head = appendVarint(wb.head, tag)
+		return
+	}
+
+	size := sizeVarint(tag)
+	if wb.hpos+size <= wb.hend {
+		appendVarint((*wb.out)[wb.hpos:wb.hpos], tag)
+	}
+
+	wb.hpos += size
 }
 
 // write appends v to the body of the writebuffer and a varint tag describing its offset and the given WireType.
 // The writebuffer.offset value is incremented by the length of v after both buffers have been updated.
 func (wb *writebuffer) write(w WireType, v []byte) {
-	wb.head = appendVarint(wb.head, (wb.offset<<4)|uint64(w))
-	wb.body = append(wb.body, v...)
+	wb.writeTag((wb.offset << 4) | uint64(w))
+
+	if wb.out != nil {
+		*wb.out = append(*wb.out, v...)
+	} else {
+		wb.body = append(wb.body, v...)
+	}
 
 	// Increment the offset by the number of bytes written to the body
 	wb.offset += uint64(len(v))
@@ -19,21 +74,82 @@ func (wb *writebuffer) write(w WireType, v []byte) {
 	wb.counter++
 }
 
+// writeLoad appends the contents of another writebuffer as a load to the body of the writebuffer and a varint
+// tag describing its offset and the given WireType. The load is appended without an intermediate allocation.
+//
+// If the load was written in place into the output of the writebuffer, it already follows the body of the
+// writebuffer and only its tag is written. A load whose head is not filled marks the head of the writebuffer
+// as not filled as well, so that it is detected when the outermost writebuffer is checked.
+func (wb *writebuffer) writeLoad(w WireType, load *writebuffer) {
+	if load.out != nil {
+		wb.writeTag((wb.offset << 4) | uint64(w))
+		if !load.filled() {
+			wb.hpos = wb.hend + 1
+		}
+
+		// Increment the offset by the number of bytes in the load, i.e,
+		// all the bytes in the output after the body of the writebuffer
+		wb.offset = uint64(len(*wb.out) - wb.hend)
+		// Increment counter to represent the number of written elements
+		wb.counter++
+
+		return
+	}
+
+	wb.writeTag((wb.offset << 4) | uint64(w))
+
+	if wb.out != nil {
+		*wb.out = load.appendLoad(*wb.out)
+	} else {
+		wb.body = load.appendLoad(wb.body)
+	}
+
+	// Increment the offset by the number of bytes written to the body
+	wb.offset += uint64(load.loadSize())
+	// Increment counter to represent the number of written elements
+	wb.counter++
+}
+
+// reset clears the contents of the writebuffer while retaining the capacity of its head and body.
+// A writebuffer that writes in place is detached from its output.
+func (wb *writebuffer) reset() {
+	wb.head, wb.body = wb.head[:0], wb.body[:0]
+	wb.offset, wb.counter = 0, 0
+	wb.out, wb.hpos, wb.hend = nil, 0, 0
+}
+
+// size returns the number of bytes in the contents of the writebuffer, i.e, the length of bytes().
+func (wb writebuffer) size() int {
+	return len(wb.head) + len(wb.body)
+}
+
+// loadSize returns the number of bytes in the contents of the writebuffer as a load, i.e, a load key
+// with the length of the head that is prefixed before the head followed by the body.
+func (wb writebuffer) loadSize() int {
+	return sizeVarint((uint64(len(wb.head))<<4)|uint64(WireLoad)) + wb.size()
+}
+
 // bytes returns the contents of the writebuffer as a single slice of bytes.
 // The returned bytes is the head followed by the body of the writebuffer.
 func (wb writebuffer) bytes() []byte {
-	return append(wb.head, wb.body...)
+	return wb.appendBytes(make([]byte, 0, wb.size()))
 }
 
-// load returns contents of the writebuffer as a slice of bytes tagged by a WireLoad,
-// i.e, a load key with the length of the head is prefixed before the head followed by the body.
-func (wb writebuffer) load() (buf []byte) {
-	key := (uint64(len(wb.head)) << 4) | uint64(WireLoad)
-	size := len(wb.head) + len(wb.body) + sizeVarint(key)
+// appendBytes appends the contents of the writebuffer to dst and returns the extended slice.
+// The capacity of dst is grown at most once to fit the head followed by the body of the writebuffer.
+func (wb writebuffer) appendBytes(dst []byte) []byte {
+	dst = slices.Grow(dst, wb.size())
+	dst = append(dst, wb.head...)
+
+	return append(dst, wb.body...)
+}
 
-	buf = appendVarint(make([]byte, 0, size), key)
-	buf = append(buf, wb.head...)
-	buf = append(buf, wb.body...)
+// appendLoad appends the contents of the writebuffer as a load to dst and returns the extended slice.
+// The capacity of dst is grown at most once to fit the load key followed by the head and body of the writebuffer.
+func (wb writebuffer) appendLoad(dst []byte) []byte {
+	dst = slices.Grow(dst, wb.loadSize())
+	dst = appendVarint(dst, (uint64(len(wb.head))<<4)|uint64(WireLoad))
+	dst = append(dst, wb.head...)
 
-	return
+	return append(dst, wb.body...)
 }
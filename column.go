@@ -0,0 +1,95 @@
+package polo
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Column is a wrapper for storing an object in a database column as its POLO byte form.
+// It implements the driver.Valuer and sql.Scanner interfaces for use with database/sql.
+//
+// SQL NULL is mapped to WireNull in both directions. Objects that encode to WireNull
+// (such as nil pointers, slices and maps) are stored as NULL and a NULL column is
+// scanned as the zero value of T, which is the result of decoding WireNull.
+type Column[T any] struct {
+	V T
+}
+
+// NewColumn returns a new Column for the given object
+func NewColumn[T any](value T) Column[T] {
+	return Column[T]{V: value}
+}
+
+// Value serializes the object of the Column into its POLO byte form.
+// Returns nil (SQL NULL) if the object encodes to WireNull.
+// Implements the driver.Valuer interface for Column.
+func (column Column[T]) Value() (driver.Value, error) {
+	wire, err := Encode(column.V)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsWireType(wire, WireNull) {
+		return nil, nil
+	}
+
+	return wire, nil
+}
+
+// Scan deserializes the object of the Column from its POLO byte form.
+// The source must be a []byte or string, or nil (SQL NULL) which sets the zero value of T.
+// Implements the sql.Scanner interface for Column.
+func (column *Column[T]) Scan(src any) error {
+	wire, err := scanWire(src)
+	if err != nil {
+		return err
+	}
+
+	// Decode into a new object to avoid merging with the existing object
+	value, err := Decode[T](wire)
+	if err != nil {
+		return err
+	}
+
+	column.V = value
+
+	return nil
+}
+
+// Value serializes the Document into its POLO byte form for storing it in a database column.
+// Returns nil (SQL NULL) if the Document is nil. Implements the driver.Valuer interface for Document.
+func (doc Document) Value() (driver.Value, error) {
+	if doc == nil {
+		return nil, nil
+	}
+
+	return doc.Bytes(), nil
+}
+
+// Scan deserializes the Document from the POLO byte form stored in a database column.
+// The source must be a []byte or string, or nil (SQL NULL) which sets a nil Document.
+// Implements the sql.Scanner interface for Document.
+func (doc *Document) Scan(src any) error {
+	wire, err := scanWire(src)
+	if err != nil {
+		return err
+	}
+
+	return Depolorize(doc, wire)
+}
+
+// scanWire returns the POLO wire from a database column value.
+// A nil value (SQL NULL) is returned as WireNull. Byte slices are returned as is
+// because decoding does not retain the wire (database/sql drivers may reuse it).
+func scanWire(src any) ([]byte, error) {
+	switch src := src.(type) {
+	case nil:
+		return []byte{byte(WireNull)}, nil
+	case []byte:
+		return src, nil
+	case string:
+		return []byte(src), nil
+	default:
+		return nil, fmt.Errorf("could not scan column: unsupported source type %T", src)
+	}
+}
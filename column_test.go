@@ -0,0 +1,114 @@
+package polo
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ driver.Valuer = Column[int]{}
+	_ sql.Scanner   = (*Column[int])(nil)
+	_ driver.Valuer = Document{}
+	_ sql.Scanner   = (*Document)(nil)
+)
+
+func TestColumn(t *testing.T) {
+	f := fuzz.New().NilChance(0)
+
+	for i := 0; i < 100; i++ {
+		var object MapObject
+
+		f.Fuzz(&object)
+
+		value, err := NewColumn(object).Value()
+		require.NoError(t, err)
+		require.True(t, driver.IsValue(value))
+
+		wire, err := Polorize(object)
+		require.NoError(t, err)
+		assert.Equal(t, wire, value)
+
+		scanned := new(Column[MapObject])
+		require.NoError(t, scanned.Scan(value))
+		assert.Equal(t, object, scanned.V)
+	}
+
+	t.Run("Null", func(t *testing.T) {
+		// Objects that encode to WireNull are stored as NULL
+		value, err := NewColumn[*MapObject](nil).Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+
+		value, err = NewColumn[[]string](nil).Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+
+		// NULL is scanned as the zero value
+		column := NewColumn([]string{"a"})
+		require.NoError(t, column.Scan(nil))
+		assert.Nil(t, column.V)
+
+		pointer := NewColumn(&MapObject{})
+		require.NoError(t, pointer.Scan(nil))
+		assert.Nil(t, pointer.V)
+	})
+
+	t.Run("Scan Sources", func(t *testing.T) {
+		wire, err := Polorize("orange")
+		require.NoError(t, err)
+
+		var column Column[string]
+
+		require.NoError(t, column.Scan(string(wire)))
+		assert.Equal(t, "orange", column.V)
+
+		// Scanned values must not alias the source, which drivers can reuse
+		require.NoError(t, column.Scan(wire))
+		wire[1] = 'O'
+		assert.Equal(t, "orange", column.V)
+
+		assert.EqualError(t, column.Scan(42), "could not scan column: unsupported source type int")
+		assert.EqualError(t, column.Scan([]byte{3, 1}),
+			"incompatible wire: unexpected wiretype 'posint'. expected one of: {null, word}")
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		// Scanning replaces the existing object rather than merging into it
+		wire, err := Polorize(map[string]int{"b": 2})
+		require.NoError(t, err)
+
+		column := NewColumn(map[string]int{"a": 1})
+		require.NoError(t, column.Scan(wire))
+		assert.Equal(t, map[string]int{"b": 2}, column.V)
+	})
+}
+
+func TestDocument_ValueScan(t *testing.T) {
+	doc := make(Document)
+	require.NoError(t, doc.Set("name", "orange"))
+	require.NoError(t, doc.Set("cost", 300))
+
+	value, err := doc.Value()
+	require.NoError(t, err)
+	assert.Equal(t, doc.Bytes(), value)
+
+	scanned := make(Document)
+	scanned.SetRaw("stale", Raw{0})
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, doc.Equal(scanned))
+
+	// Nil documents are stored as NULL and NULL is scanned as a nil document
+	value, err = Document(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+
+	assert.EqualError(t, scanned.Scan(3.14), "could not scan column: unsupported source type float64")
+}
@@ -0,0 +1,199 @@
+package polo
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// compressMagic is the prefix of the header of a compressed wire. The first byte is
+// a tag with a reserved wire type, which is never produced by encoding a POLO wire.
+var compressMagic = [2]byte{0x08, 'Z'}
+
+// DefaultDecompressLimit is the maximum size of a decompressed
+// wire if no limit is given to Decompress or Decompression.
+const DefaultDecompressLimit = 64 << 20
+
+// decompressPrealloc is the multiple of the size of compressed data
+// that is preallocated for decompressing it into a POLO wire
+const decompressPrealloc = 8
+
+// Codec is an identifier for the compression algorithm of a compressed wire
+type Codec byte
+
+// Codec enums
+const (
+	// CodecDeflate is the identifier for DEFLATE (RFC 1951) compression
+	CodecDeflate Codec = 1
+	// CodecGzip is the identifier for gzip (RFC 1952) compression
+	CodecGzip Codec = 2
+)
+
+// Compressor is an interface for a compression algorithm that can be used with Compress and Decompress.
+// Compressors other than DEFLATE and gzip must be registered with RegisterCompressor to be decompressed.
+type Compressor interface {
+	// Codec returns the identifier of the compression algorithm that is written into the header
+	Codec() Codec
+	// NewWriter returns a writer that compresses data into w. The data is flushed when the writer is closed.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	// NewReader returns a reader that decompresses data from r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// deflateCompressor is a Compressor for DEFLATE with a compression level
+type deflateCompressor struct {
+	level int
+}
+
+// Deflate returns a Compressor for DEFLATE with the given compression level from the compress/flate package
+func Deflate(level int) Compressor {
+	return deflateCompressor{level}
+}
+
+func (compressor deflateCompressor) Codec() Codec {
+	return CodecDeflate
+}
+
+func (compressor deflateCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return flate.NewWriter(w, compressor.level)
+}
+
+func (compressor deflateCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return flate.NewReader(r), nil
+}
+
+// gzipCompressor is a Compressor for gzip with a compression level
+type gzipCompressor struct {
+	level int
+}
+
+// Gzip returns a Compressor for gzip with the given compression level from the compress/gzip package
+func Gzip(level int) Compressor {
+	return gzipCompressor{level}
+}
+
+func (compressor gzipCompressor) Codec() Codec {
+	return CodecGzip
+}
+
+func (compressor gzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(w, compressor.level)
+}
+
+func (compressor gzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// compressors is the registry of Compressor objects by their Codec
+var compressors = struct {
+	sync.RWMutex
+	registry map[Codec]Compressor
+}{
+	registry: map[Codec]Compressor{
+		CodecDeflate: Deflate(flate.DefaultCompression),
+		CodecGzip:    Gzip(gzip.DefaultCompression),
+	},
+}
+
+// RegisterCompressor registers a Compressor so that wires compressed with it can be decompressed.
+// Any Compressor previously registered for the same Codec (including the builtin ones) is replaced.
+func RegisterCompressor(compressor Compressor) {
+	compressors.Lock()
+	defer compressors.Unlock()
+
+	compressors.registry[compressor.Codec()] = compressor
+}
+
+// IsCompressed returns whether some data is a compressed wire, i.e, it starts with a compression header.
+func IsCompressed(data []byte) bool {
+	return len(data) >= len(compressMagic) && [2]byte(data[:2]) == compressMagic
+}
+
+// Compress compresses some POLO wire with the given Compressor and prefixes it with a header that identifies
+// the codec and the size of the wire. The header is the bytes 0x08 and 'Z', followed by the codec and the size as a
+// varint. The returned data can be restored with Decompress or decoded with the Decompression option.
+func Compress(wire []byte, compressor Compressor) ([]byte, error) {
+	// Write the header for the compressed wire
+	buffer := bytes.NewBuffer(make([]byte, 0, len(wire)/2))
+	buffer.Write(compressMagic[:])
+	buffer.WriteByte(byte(compressor.Codec()))
+	buffer.Write(appendVarint(nil, uint64(len(wire))))
+
+	// Compress the wire into the buffer
+	writer, err := compressor.NewWriter(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("could not compress wire: %w", err)
+	}
+
+	if _, err = writer.Write(wire); err != nil {
+		return nil, fmt.Errorf("could not compress wire: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("could not compress wire: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Decompress restores a POLO wire that was compressed with Compress. The size of the decompressed wire
+// must not exceed the given limit (DefaultDecompressLimit is used if limit is not positive), which is
+// enforced on both the size in the header and the actual decompressed data to guard against zip bombs.
+// Returns an error if the data is not a compressed wire or if the codec is not registered.
+func Decompress(data []byte, limit int) ([]byte, error) {
+	if limit <= 0 {
+		limit = DefaultDecompressLimit
+	}
+
+	if !IsCompressed(data) || len(data) < 3 {
+		return nil, errors.New("could not decompress wire: missing compression header")
+	}
+
+	// Lookup the compressor for the codec
+	compressors.RLock()
+	compressor, ok := compressors.registry[Codec(data[2])]
+	compressors.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("could not decompress wire: unknown codec %v", data[2])
+	}
+
+	// Read the size of the wire and check it against the limit.
+	// The compressed data is read from the same reader after the size.
+	source := bytes.NewReader(data[3:])
+
+	size, _, err := consumeVarint(source)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress wire: malformed size: %w", err)
+	}
+
+	if size > uint64(limit) {
+		return nil, fmt.Errorf("%w: size %v exceeds limit %v", ErrDecompressLimit, size, limit)
+	}
+
+	reader, err := compressor.NewReader(source)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress wire: %w", err)
+	}
+
+	defer reader.Close()
+
+	// Read at most one byte more than the expected size to detect a size mismatch.
+	// The buffer is not preallocated beyond a multiple of the compressed data, as the
+	// size in the header is unverified until the data is read, and grows as required.
+	buffer := bytes.NewBuffer(make([]byte, 0, min(size+1, uint64(len(data))*decompressPrealloc)))
+
+	if _, err = buffer.ReadFrom(io.LimitReader(reader, int64(size)+1)); err != nil {
+		return nil, fmt.Errorf("could not decompress wire: %w", err)
+	}
+
+	if uint64(buffer.Len()) != size {
+		return nil, fmt.Errorf("could not decompress wire: size mismatch: expected %v", size)
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,211 @@
+package polo
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// identityCompressor is a Compressor that does not compress its data
+type identityCompressor struct{}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func (identityCompressor) Codec() Codec { return 200 }
+
+func (identityCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+func (identityCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(r), nil
+}
+
+func TestCompress(t *testing.T) {
+	// A large document with repetitive keys
+	doc := make(Document)
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, doc.Set(fmt.Sprintf("account-balance-%04d", i), uint64(i)))
+	}
+
+	wire := doc.Bytes()
+
+	RegisterCompressor(identityCompressor{})
+
+	for _, compressor := range []Compressor{
+		Deflate(flate.BestCompression),
+		Gzip(gzip.DefaultCompression),
+		identityCompressor{},
+	} {
+		t.Run(fmt.Sprintf("%T", compressor), func(t *testing.T) {
+			compressed, err := Compress(wire, compressor)
+			require.NoError(t, err)
+			assert.True(t, IsCompressed(compressed))
+			assert.Equal(t, byte(compressor.Codec()), compressed[2])
+
+			if compressor.Codec() != 200 {
+				assert.Less(t, len(compressed), len(wire)/2)
+			}
+
+			decompressed, err := Decompress(compressed, 0)
+			require.NoError(t, err)
+			assert.Equal(t, wire, decompressed)
+		})
+	}
+
+	assert.False(t, IsCompressed(wire))
+	assert.False(t, IsCompressed([]byte{'P'}))
+}
+
+func TestDecompress_Errors(t *testing.T) {
+	wire := bytes.Repeat([]byte{6}, 1<<16)
+
+	compressed, err := Compress(wire, Gzip(gzip.BestCompression))
+	require.NoError(t, err)
+
+	t.Run("Limit", func(t *testing.T) {
+		_, err := Decompress(compressed, 1<<10)
+		assert.ErrorIs(t, err, ErrDecompressLimit)
+		assert.EqualError(t, err, "decompressed wire exceeds limit: size 65536 exceeds limit 1024")
+
+		_, err = Decompress(compressed, 1<<16)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Forged Size", func(t *testing.T) {
+		// A header that understates the size must not decompress beyond it
+		forged := append([]byte{0x08, 'Z', byte(CodecGzip)}, appendVarint(nil, 1024)...)
+		forged = append(forged, compressed[3+sizeVarint(1<<16):]...)
+
+		_, err := Decompress(forged, 1<<10)
+		assert.EqualError(t, err, "could not decompress wire: size mismatch: expected 1024")
+	})
+
+	t.Run("Preallocation", func(t *testing.T) {
+		// A header that overstates the size must not be trusted for the allocation
+		small, err := Compress([]byte{6}, Gzip(gzip.BestCompression))
+		require.NoError(t, err)
+
+		forged := append([]byte{0x08, 'Z', byte(CodecGzip)}, appendVarint(nil, DefaultDecompressLimit)...)
+		forged = append(forged, small[4:]...)
+
+		var before, after runtime.MemStats
+
+		runtime.ReadMemStats(&before)
+		_, err = Decompress(forged, 0)
+		runtime.ReadMemStats(&after)
+
+		assert.EqualError(t, err, fmt.Sprintf("could not decompress wire: size mismatch: expected %v",
+			DefaultDecompressLimit))
+		assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		tests := []struct {
+			data []byte
+			err  string
+		}{
+			{[]byte{14, 31}, "could not decompress wire: missing compression header"},
+			{[]byte{0x08, 'Z'}, "could not decompress wire: missing compression header"},
+			{[]byte{0x08, 'Z', 99, 0}, "could not decompress wire: unknown codec 99"},
+			{[]byte{0x08, 'Z', byte(CodecDeflate), 0x80}, "could not decompress wire: malformed size: " +
+				"varint terminated prematurely"},
+			{[]byte{0x08, 'Z', byte(CodecGzip), 10, 1, 2, 3}, "could not decompress wire: unexpected EOF"},
+			{compressed[:len(compressed)/2], "could not decompress wire: unexpected EOF"},
+		}
+
+		for _, test := range tests {
+			_, err := Decompress(test.data, 0)
+			assert.EqualError(t, err, test.err)
+		}
+	})
+}
+
+func TestCompression(t *testing.T) {
+	type Ledger struct {
+		Accounts map[string]uint64
+	}
+
+	ledger := Ledger{Accounts: make(map[string]uint64)}
+	for i := 0; i < 500; i++ {
+		ledger.Accounts[fmt.Sprintf("account-%04d", i)] = uint64(i * 1000)
+	}
+
+	plain, err := Polorize(ledger)
+	require.NoError(t, err)
+
+	compressed, err := Polorize(ledger, Compression(Deflate(flate.DefaultCompression)))
+	require.NoError(t, err)
+	assert.True(t, IsCompressed(compressed))
+	assert.Less(t, len(compressed), len(plain))
+
+	encoded, err := Encode(ledger, Compression(Deflate(flate.DefaultCompression)))
+	require.NoError(t, err)
+	assert.Equal(t, compressed, encoded)
+
+	appended, err := AppendPolorize([]byte{1, 2}, ledger, Compression(Deflate(flate.DefaultCompression)))
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{1, 2}, compressed...), appended)
+
+	// Compressed wires can only be decoded with decompression
+	decoded := new(Ledger)
+	assert.ErrorIs(t, Depolorize(decoded, compressed), ErrCompressedWire)
+	assert.Equal(t, Ledger{}, *decoded)
+
+	_, err = Decode[Ledger](compressed)
+	assert.ErrorIs(t, err, ErrCompressedWire)
+
+	require.NoError(t, Depolorize(decoded, compressed, Decompression(0)))
+	assert.Equal(t, ledger, *decoded)
+
+	// Uncompressed wires are decoded as is
+	decoded = new(Ledger)
+	require.NoError(t, Depolorize(decoded, plain, Decompression(0)))
+	assert.Equal(t, ledger, *decoded)
+
+	// Decompression limits are enforced
+	err = Depolorize(decoded, compressed, Decompression(1024))
+	assert.ErrorIs(t, err, ErrDecompressLimit)
+
+	generic, err := Decode[Ledger](compressed, Decompression(0))
+	require.NoError(t, err)
+	assert.Equal(t, ledger, generic)
+
+	t.Run("Embedded", func(t *testing.T) {
+		gzipped := Compression(Gzip(gzip.DefaultCompression))
+
+		// Document values are never compressed
+		doc := make(Document)
+		require.NoError(t, doc.Set("ledger", ledger, gzipped))
+		require.NoError(t, doc.SetPath("nested.ledger", ledger, gzipped))
+
+		for _, path := range []string{"ledger", "nested.ledger"} {
+			value := new(Ledger)
+			require.NoError(t, doc.GetPath(path, value))
+			assert.Equal(t, ledger, *value)
+		}
+
+		// Envelope payloads are never compressed
+		_, signer, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		envelope, err := Seal(ledger, signer, gzipped)
+		require.NoError(t, err)
+		assert.Equal(t, Raw(plain), envelope.Payload)
+
+		opened := new(Ledger)
+		require.NoError(t, Open(envelope, signer.Public(), opened))
+		assert.Equal(t, ledger, *opened)
+	})
+}
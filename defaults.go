@@ -0,0 +1,116 @@
+package polo
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Defaulter is an interface for a struct that fills its own default values.
+// When a struct is decoded, PoloDefaults is called before any of its fields are decoded,
+// so that fields which are absent from the wire (or WireNull) retain their default values.
+type Defaulter interface {
+	PoloDefaults()
+}
+
+// applyDefaults fills the default values of a new struct before it is decoded.
+// Fields with a 'default' option in their polo tag are set first, followed by a call to
+// PoloDefaults if the struct implements Defaulter. The struct value must be addressable.
+func applyDefaults(structure reflect.Value) error {
+	target := structure.Type()
+
+	// Set the defaults from the struct tags
+	for index, tag := range fieldTags(target) {
+		if tag.skip || !tag.hasDefault {
+			continue
+		}
+
+		field := target.Field(index)
+
+		value, err := parseDefault(field.Type, tag.def)
+		if err != nil {
+			return IncompatibleValueError{
+				fmt.Sprintf("invalid default for struct field [%v.%v <%v>]: %v", target, field.Name, field.Type, err),
+			}
+		}
+
+		structure.Field(index).Set(value)
+	}
+
+	// Call PoloDefaults if the struct implements Defaulter
+	if defaulter, ok := structure.Addr().Interface().(Defaulter); ok {
+		defaulter.PoloDefaults()
+	}
+
+	return nil
+}
+
+// parseDefault parses a default value from its string form into a value of the given type.
+// Supports booleans, integers, floats, strings, big integers and pointers to them.
+func parseDefault(target reflect.Type, def string) (reflect.Value, error) {
+	value := reflect.New(target).Elem()
+
+	// Big Integers (base prefixes such as 0x are accepted)
+	if target == reflect.TypeOf(*big.NewInt(0)) {
+		bigint, ok := new(big.Int).SetString(def, 0)
+		if !ok {
+			return zeroVal, fmt.Errorf("invalid big integer '%v'", def)
+		}
+
+		value.Set(reflect.ValueOf(*bigint))
+
+		return value, nil
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr:
+		elem, err := parseDefault(target.Elem(), def)
+		if err != nil {
+			return zeroVal, err
+		}
+
+		value.Set(reflect.New(target.Elem()))
+		value.Elem().Set(elem)
+
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(def)
+		if err != nil {
+			return zeroVal, err
+		}
+
+		value.SetBool(parsed)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(def, 0, target.Bits())
+		if err != nil {
+			return zeroVal, err
+		}
+
+		value.SetInt(parsed)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(def, 0, target.Bits())
+		if err != nil {
+			return zeroVal, err
+		}
+
+		value.SetUint(parsed)
+
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(def, target.Bits())
+		if err != nil {
+			return zeroVal, err
+		}
+
+		value.SetFloat(parsed)
+
+	case reflect.String:
+		value.SetString(def)
+
+	default:
+		return zeroVal, fmt.Errorf("unsupported type: %v", target)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,154 @@
+package polo
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type DefaultsV1 struct {
+	Name string
+}
+
+type DefaultsV2 struct {
+	Name    string
+	Retries int      `polo:"retries,default=3"`
+	Ratio   float64  `polo:",default=0.5"`
+	Enabled bool     `polo:",default=true"`
+	Region  string   `polo:",default=us-east"`
+	Limit   *uint16  `polo:",default=0x100"`
+	Supply  *big.Int `polo:",default=1000000000000000000000"`
+	Tags    []string
+}
+
+type DefaulterV2 struct {
+	Name    string
+	Retries int `polo:",default=3"`
+	Tags    []string
+}
+
+func (object *DefaulterV2) PoloDefaults() {
+	object.Retries *= 2
+	object.Tags = []string{"default"}
+}
+
+func TestDefaults(t *testing.T) {
+	limit := uint16(256)
+	supply, _ := new(big.Int).SetString("1000000000000000000000", 10)
+
+	expected := DefaultsV2{
+		Name:    "orange",
+		Retries: 3,
+		Ratio:   0.5,
+		Enabled: true,
+		Region:  "us-east",
+		Limit:   &limit,
+		Supply:  supply,
+	}
+
+	t.Run("Document Missing", func(t *testing.T) {
+		wire, err := Polorize(DefaultsV1{"orange"}, DocStructs())
+		require.NoError(t, err)
+
+		object := new(DefaultsV2)
+		require.NoError(t, Depolorize(object, wire, DocStructs()))
+		assert.Equal(t, expected, *object)
+	})
+
+	t.Run("Document Null", func(t *testing.T) {
+		doc := make(Document)
+		require.NoError(t, doc.Set("Name", "orange"))
+		doc.SetRaw("retries", Raw{0})
+		doc.SetRaw("Supply", Raw{0})
+
+		object := new(DefaultsV2)
+		require.NoError(t, Depolorize(object, doc.Bytes(), DocStructs()))
+		assert.Equal(t, expected, *object)
+	})
+
+	t.Run("Pack Null", func(t *testing.T) {
+		polorizer := NewPolorizer()
+		polorizer.PolorizeString("orange")
+
+		for i := 0; i < 7; i++ {
+			polorizer.PolorizeNull()
+		}
+
+		object := new(DefaultsV2)
+		require.NoError(t, Depolorize(object, polorizer.Packed()))
+		assert.Equal(t, expected, *object)
+	})
+
+	t.Run("Explicit Zero", func(t *testing.T) {
+		zero := uint16(0)
+		explicit := DefaultsV2{Name: "orange", Limit: &zero, Supply: big.NewInt(0)}
+
+		wire, err := Polorize(explicit, DocStructs())
+		require.NoError(t, err)
+
+		object := new(DefaultsV2)
+		require.NoError(t, Depolorize(object, wire, DocStructs()))
+		assert.Equal(t, explicit, *object)
+	})
+
+	t.Run("Defaulter", func(t *testing.T) {
+		wire, err := Polorize(DefaultsV1{"orange"}, DocStructs())
+		require.NoError(t, err)
+
+		object := new(DefaulterV2)
+		require.NoError(t, Depolorize(object, wire, DocStructs()))
+		assert.Equal(t, DefaulterV2{"orange", 6, []string{"default"}}, *object)
+
+		wire, err = Polorize(DefaulterV2{"apple", 1, []string{"red"}})
+		require.NoError(t, err)
+
+		require.NoError(t, Depolorize(object, wire))
+		assert.Equal(t, DefaulterV2{"apple", 1, []string{"red"}}, *object)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		type Invalid struct {
+			Count int8 `polo:",default=1000"`
+		}
+
+		wire, err := Polorize(DefaultsV1{"orange"}, DocStructs())
+		require.NoError(t, err)
+
+		err = Depolorize(new(Invalid), wire, DocStructs())
+		assert.EqualError(t, err, "incompatible value error: invalid default for struct field [polo.Invalid.Count <int8>]: "+
+			"strconv.ParseInt: parsing \"1000\": value out of range")
+	})
+}
+
+func TestParseDefault(t *testing.T) {
+	tests := []struct {
+		target   reflect.Type
+		def      string
+		expected any
+		err      string
+	}{
+		{reflect.TypeOf(false), "true", true, ""},
+		{reflect.TypeOf(int32(0)), "-42", int32(-42), ""},
+		{reflect.TypeOf(uint8(0)), "0xff", uint8(255), ""},
+		{reflect.TypeOf(float32(0)), "1.5", float32(1.5), ""},
+		{reflect.TypeOf(""), "hello", "hello", ""},
+		{reflect.TypeOf(*big.NewInt(0)), "-0x10", *big.NewInt(-16), ""},
+		{reflect.TypeOf(false), "yes", nil, "strconv.ParseBool: parsing \"yes\": invalid syntax"},
+		{reflect.TypeOf(*big.NewInt(0)), "ten", nil, "invalid big integer 'ten'"},
+		{reflect.TypeOf([]string{}), "a", nil, "unsupported type: []string"},
+	}
+
+	for _, test := range tests {
+		value, err := parseDefault(test.target, test.def)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, value.Interface())
+	}
+}
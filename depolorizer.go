@@ -3,8 +3,10 @@ package polo
 import (
 	"errors"
 	"fmt"
+	"iter"
 	"math/big"
 	"reflect"
+	"strings"
 )
 
 // Depolorizer is a decoding buffer that can sequentially depolorize object from it.
@@ -32,16 +34,52 @@ func NewDepolorizer(data []byte, options ...EncodingOptions) (*Depolorizer, erro
 		opt(config)
 	}
 
+	// Decompress the wire if it is compressed, which requires decompression to be configured
+	if IsCompressed(data) {
+		if !config.decompress {
+			return nil, ErrCompressedWire
+		}
+
+		decompressed, err := Decompress(data, config.decompressLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		data = decompressed
+	}
+
 	// Create a new readbuffer from the wire
 	rb, err := newreadbuffer(data)
 	if err != nil {
 		return nil, err
 	}
 
+	// Validate the wire against the schema, if one is configured
+	if config.schema != nil {
+		if err = validateSchema(rb, config); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create a non-pack Depolorizer
 	return &Depolorizer{data: rb, cfg: *config}, nil
 }
 
+// validateSchema decodes the readbuffer as a document and validates it against the schema of the config.
+// Returns an error if the readbuffer is not a document or if it does not conform to the schema.
+func validateSchema(rb readbuffer, config *wireConfig) error {
+	if rb.wire != WireDoc {
+		return IncompatibleWireType(rb.wire, WireDoc)
+	}
+
+	doc, err := rb.decodeDocument(config)
+	if err != nil {
+		return err
+	}
+
+	return doc.Validate(config.schema)
+}
+
 // newLoadDepolorizer returns a new Depolorizer from a given readbuffer.
 // The readbuffer is converted into a packbuffer and the returned Depolorizer is created in packed mode.
 func newLoadDepolorizer(data readbuffer, config *wireConfig) (*Depolorizer, error) {
@@ -84,15 +122,8 @@ func (depolorizer *Depolorizer) Done() bool {
 //
 // Use DepolorizeNull to consume the WireNull element if further elements are to be read.
 func (depolorizer *Depolorizer) IsNull() bool {
-	// Check if the Depolorizer is in packed mode
-	if !depolorizer.packed {
-		// Return the nullity of the atomic buffer
-		return depolorizer.data.wire.IsNull()
-	}
-
-	// Peek the next element from the packbuffer.
-	// Errors if there are no elements left
-	peek, ok := depolorizer.pack.peek()
+	// Peek the next element, if there are no elements left
+	peek, ok := depolorizer.Peek()
 	if !ok {
 		return false
 	}
@@ -101,6 +132,80 @@ func (depolorizer *Depolorizer) IsNull() bool {
 	return peek.IsNull()
 }
 
+// Peek returns the WireType of the next element in the Depolorizer without consuming it from the buffer.
+// Returns (WireNull, false) if there are no elements left in the Depolorizer.
+func (depolorizer *Depolorizer) Peek() (WireType, bool) {
+	// Peek the next element from the packbuffer if in packed mode
+	if depolorizer.packed {
+		return depolorizer.pack.peek()
+	}
+
+	// Check if the atomic buffer has been read
+	if depolorizer.done {
+		return WireNull, false
+	}
+
+	return depolorizer.data.wire, true
+}
+
+// Skip consumes the next element in the Depolorizer without decoding it.
+// Returns an error if there are no elements left or if the element tag is malformed.
+func (depolorizer *Depolorizer) Skip() error {
+	_, err := depolorizer.read()
+	return err //nolint:nlreturn
+}
+
+// Remaining returns the number of elements in the Depolorizer that have not been read.
+// A Depolorizer that is not in packed mode only has a single element.
+func (depolorizer *Depolorizer) Remaining() int {
+	// Count the remaining elements in the packbuffer if in packed mode
+	if depolorizer.packed {
+		return depolorizer.pack.remaining()
+	}
+
+	if depolorizer.done {
+		return 0
+	}
+
+	return 1
+}
+
+// Position returns the index of the next element in the Depolorizer,
+// i.e, the number of elements that have been read from it.
+func (depolorizer *Depolorizer) Position() int {
+	// Determine the position from the remaining elements in the packbuffer if in packed mode
+	if depolorizer.packed {
+		return depolorizer.pack.size - depolorizer.pack.remaining()
+	}
+
+	if depolorizer.done {
+		return 1
+	}
+
+	return 0
+}
+
+// Elements returns an iterator over the remaining elements in the Depolorizer, consuming each element
+// as the iteration proceeds. Each element is yielded as an Any along with its position in the Depolorizer.
+// Iteration stops early if an element is malformed, which can be detected with Done() after the loop.
+func (depolorizer *Depolorizer) Elements() iter.Seq2[int, Any] {
+	return func(yield func(int, Any) bool) {
+		for !depolorizer.Done() {
+			position := depolorizer.Position()
+
+			// Read the next element as an Any
+			element, err := depolorizer.DepolorizeAny()
+			if err != nil {
+				return
+			}
+
+			if !yield(position, element) {
+				return
+			}
+		}
+	}
+}
+
 // Depolorize decodes a value from the Depolorizer.
 // Decodes the data in the wire into the given object using Go reflection.
 // Returns an error if the object is not a pointer or if a decode error occurs.
@@ -116,8 +221,14 @@ func (depolorizer *Depolorizer) Depolorize(object any) error {
 		return ErrObjectNotSettable
 	}
 
+	return depolorizer.depolorizeInto(value.Elem())
+}
+
+// depolorizeInto decodes the next element from the Depolorizer into the given settable value.
+// The value is left unmodified if the element is a WireNull.
+func (depolorizer *Depolorizer) depolorizeInto(value reflect.Value) error {
 	// Obtain the type of the underlying type
-	target := value.Type().Elem()
+	target := value.Type()
 	// Depolorize the next element to the target type
 	result, err := depolorizer.depolorizeValue(target)
 
@@ -132,7 +243,7 @@ func (depolorizer *Depolorizer) Depolorize(object any) error {
 	}
 
 	// Convert and set the decoded value
-	value.Elem().Set(result.Convert(target))
+	value.Set(result.Convert(target))
 
 	return nil
 }
@@ -175,7 +286,7 @@ func (depolorizer *Depolorizer) DepolorizeBytes() ([]byte, error) {
 		return nil, err
 	}
 
-	return allowNilValue(data.decodeBytes(depolorizer.cfg.packBytes))
+	return allowNilValue(data.decodeBytes(depolorizer.cfg.packBytes, depolorizer.cfg.zeroCopy))
 }
 
 // DepolorizeBytes32 attempts to decode a 32-byte value from the Depolorizer, consuming one wire element.
@@ -204,7 +315,7 @@ func (depolorizer *Depolorizer) DepolorizeString() (string, error) {
 		return "", err
 	}
 
-	return allowNilValue(data.decodeString())
+	return allowNilValue(data.decodeString(depolorizer.cfg.zeroCopy))
 }
 
 // DepolorizeBool attempts to decode a bool value from the Depolorizer, consuming one wire element.
@@ -389,7 +500,7 @@ func (depolorizer *Depolorizer) DepolorizeDocument() (Document, error) {
 		return nil, err
 	}
 
-	return data.decodeDocument()
+	return data.decodeDocument(&depolorizer.cfg)
 }
 
 // DepolorizeAny attempts to decode an Any from the Depolorizer, consuming one wire element.
@@ -402,7 +513,7 @@ func (depolorizer *Depolorizer) DepolorizeAny() (Any, error) {
 		return nil, err
 	}
 
-	return data.asAny(), nil
+	return data.asAny(depolorizer.cfg.zeroCopy), nil
 }
 
 // DepolorizeRaw attempts to decode a Raw from the Depolorizer, consuming one wire element.
@@ -414,7 +525,7 @@ func (depolorizer *Depolorizer) DepolorizeRaw() (Raw, error) {
 		return nil, err
 	}
 
-	return data.asRaw()
+	return data.asRaw(depolorizer.cfg.zeroCopy)
 }
 
 // DepolorizePacked attempts to decode another Depolorizer from the Depolorizer, consuming one wire element.
@@ -446,8 +557,8 @@ func (depolorizer *Depolorizer) depolorizeInner() (*Depolorizer, error) {
 		return nil, err
 	}
 
-	// Create a non-pack Depolorizer
-	return &Depolorizer{data: data}, nil
+	// Create a non-pack Depolorizer (inherit configuration)
+	return &Depolorizer{data: data, cfg: depolorizer.cfg}, nil
 }
 
 // depolorizeByteArrayValue accepts a reflect.Type and decodes a byte array from the Depolorizer.
@@ -622,8 +733,15 @@ func (depolorizer *Depolorizer) depolorizeMapValue(target reflect.Type) (reflect
 				return zeroVal, err
 			}
 
-			// Create a value for key
-			mapKey := key.Convert(keyType)
+			// Create a value for key based on nullity of k
+			var mapKey reflect.Value
+			if key == zeroVal {
+				// Create a nil value
+				mapKey = reflect.New(keyType).Elem()
+			} else {
+				// Reflect value of k and convert type
+				mapKey = key.Convert(keyType)
+			}
 
 			// Create a value for val based on nullity of v
 			var mapVal reflect.Value
@@ -649,7 +767,7 @@ func (depolorizer *Depolorizer) depolorizeMapValue(target reflect.Type) (reflect
 		}
 
 		// Decode the wire object into a Document
-		doc, err := data.decodeDocument()
+		doc, err := data.decodeDocument(&depolorizer.cfg)
 		if err != nil {
 			return zeroVal, err
 		}
@@ -705,22 +823,41 @@ func (depolorizer *Depolorizer) depolorizeStructValue(target reflect.Type) (refl
 			return zeroVal, err
 		}
 
-		// Create a new struct instance
+		// Find the field that collects trailing pack elements
+		rest, err := restField(target)
+		if err != nil {
+			return zeroVal, err
+		}
+
+		// Create a new struct instance with its default values
 		structure := reflect.New(target).Elem()
+		if err = applyDefaults(structure); err != nil {
+			return zeroVal, err
+		}
 
 		// Iterate on struct fields
-		for index := 0; index < target.NumField(); index++ {
-			// Obtain field data for field index
-			field := target.Field(index)
-
+		for index, tag := range fieldTags(target) {
 			// Skip the field if it is not exported or if it
 			// is manually tagged to be skipped with a '-' tag
-			if !field.IsExported() || field.Tag.Get("polo") == "-" {
+			if tag.skip || tag.rest {
 				continue
 			}
 
-			// Depolorize the next object from the pack into the map key type
+			// Obtain field data for field index
+			field := target.Field(index)
+
+			// Missing trailing fields are left with their default values, if allowed
+			if pack.Done() && depolorizer.cfg.allowMissing {
+				break
+			}
+
+			// Depolorize the next object from the pack into the map key type.
+			// Null elements leave the field with its default value.
 			val, err := pack.depolorizeValue(field.Type)
+			if errors.Is(err, errNilValue) {
+				continue
+			}
+
 			if err != nil {
 				return zeroVal, IncompatibleWireError{
 					fmt.Sprintf("struct field [%v.%v <%v>]: %v", target, field.Name, field.Type, err),
@@ -732,6 +869,29 @@ func (depolorizer *Depolorizer) depolorizeStructValue(target reflect.Type) (refl
 			}
 		}
 
+		switch {
+		case pack.Done():
+		// Trailing elements are kept in the rest field if there is one
+		case rest != -1:
+			var elements []Any
+
+			for !pack.Done() {
+				element, err := pack.DepolorizeAny()
+				if err != nil {
+					return zeroVal, err
+				}
+
+				elements = append(elements, element)
+			}
+
+			structure.Field(rest).Set(reflect.ValueOf(elements))
+		// Trailing elements are rejected if they are disallowed
+		case depolorizer.cfg.disallowTrailing:
+			return zeroVal, IncompatibleWireError{
+				fmt.Sprintf("struct [%v]: %v unexpected trailing elements", target, pack.Remaining()),
+			}
+		}
+
 		return structure, nil
 
 	case WireDoc:
@@ -739,36 +899,52 @@ func (depolorizer *Depolorizer) depolorizeStructValue(target reflect.Type) (refl
 			return zeroVal, IncompatibleWireType(data.wire, WireNull, WirePack)
 		}
 
-		doc, err := data.decodeDocument()
+		doc, err := data.decodeDocument(&depolorizer.cfg)
 		if err != nil {
 			return zeroVal, err
 		}
 
-		// Create a new struct instance
+		// Find the field that collects unknown document keys
+		unknown, err := unknownField(target)
+		if err != nil {
+			return zeroVal, err
+		}
+
+		// Create a new struct instance with its default values
 		structure := reflect.New(target).Elem()
+		if err = applyDefaults(structure); err != nil {
+			return zeroVal, err
+		}
+		// Track the document keys that are matched by a struct field
+		matched := make(map[string]struct{}, len(doc))
 
-		// Iterate on struct fields
-		for index := 0; index < target.NumField(); index++ {
-			// Obtain field data for field index
-			field := target.Field(index)
+		// Sorted document keys are required for case-insensitive matching
+		var keys []string
+		if depolorizer.cfg.caseInsensitive {
+			keys = doc.Keys()
+		}
 
+		// Iterate on struct fields
+		for index, tag := range fieldTags(target) {
 			// Skip the field if it is not exported or if it
 			// is manually tagged to be skipped with a '-' tag
-			tag := field.Tag.Get("polo")
-			if !field.IsExported() || tag == "-" {
+			if tag.skip || tag.unknown {
 				continue
 			}
 
-			// Determine doc key for struct field. Field name is used
-			// directly if there is no provided in the polo tag.
-			fieldName := field.Name
-			if tag != "" {
-				fieldName = tag
-			}
+			// Obtain field data for field index
+			field := target.Field(index)
 
 			// Retrieve the data for the field from the document,
 			// if there is no data for the key, skip the field
-			data := doc.GetRaw(fieldName)
+			key, ok := tag.lookup(doc, keys)
+			if !ok {
+				continue
+			}
+
+			matched[key] = struct{}{}
+
+			data := doc[key]
 			if data == nil {
 				continue
 			}
@@ -778,8 +954,13 @@ func (depolorizer *Depolorizer) depolorizeStructValue(target reflect.Type) (refl
 				return zeroVal, err
 			}
 
+			// Null values leave the field with its default value
 			fieldVal, err := object.depolorizeValue(field.Type)
-			if err != nil && !errors.Is(err, errNilValue) {
+			if errors.Is(err, errNilValue) {
+				continue
+			}
+
+			if err != nil {
 				return zeroVal, IncompatibleWireError{
 					fmt.Sprintf("struct field [%v.%v <%v>]: %v", target, field.Name, field.Type, err),
 				}
@@ -790,6 +971,33 @@ func (depolorizer *Depolorizer) depolorizeStructValue(target reflect.Type) (refl
 			}
 		}
 
+		// Collect the document keys that were not matched by any struct field
+		var extra Document
+
+		for key, data := range doc {
+			if _, ok := matched[key]; ok {
+				continue
+			}
+
+			if extra == nil {
+				extra = make(Document)
+			}
+
+			extra[key] = data
+		}
+
+		switch {
+		case extra == nil:
+		// Unknown keys are kept in the unknown field if there is one
+		case unknown != -1:
+			structure.Field(unknown).Set(reflect.ValueOf(extra))
+		// Unknown keys are rejected if they are disallowed
+		case depolorizer.cfg.disallowUnknown:
+			return zeroVal, IncompatibleWireError{
+				fmt.Sprintf("struct [%v]: unknown document keys [%v]", target, strings.Join(extra.Keys(), ", ")),
+			}
+		}
+
 		return structure, nil
 
 	// Null Struct
@@ -874,7 +1082,7 @@ func (depolorizer *Depolorizer) depolorizeValue(target reflect.Type) (reflect.Va
 			return zeroVal, err
 		}
 
-		return reflected(data.decodeString())
+		return reflected(data.decodeString(depolorizer.cfg.zeroCopy))
 
 	// Uint8 Value
 	case reflect.Uint8:
@@ -5,6 +5,7 @@ import (
 	"log"
 	"math/big"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -80,19 +81,19 @@ func TestNewDepolorizer(t *testing.T) {
 			"null wire",
 			[]byte{0},
 			"",
-			&Depolorizer{data: readbuffer{WireNull, []byte{}}},
+			&Depolorizer{data: readbuffer{WireNull, []byte{}, []byte{0}}},
 		},
 		{
 			"posint wire",
 			[]byte{3, 1, 44},
 			"",
-			&Depolorizer{data: readbuffer{WirePosInt, []byte{1, 44}}},
+			&Depolorizer{data: readbuffer{WirePosInt, []byte{1, 44}, []byte{3, 1, 44}}},
 		},
 		{
 			"pack wire",
 			[]byte{14, 47, 3, 35, 1, 44, 250},
 			"",
-			&Depolorizer{data: readbuffer{WirePack, []byte{47, 3, 35, 1, 44, 250}}},
+			&Depolorizer{data: readbuffer{WirePack, []byte{47, 3, 35, 1, 44, 250}, []byte{14, 47, 3, 35, 1, 44, 250}}},
 		},
 		{
 			"malformed wire",
@@ -586,11 +587,11 @@ func TestDepolorizer_depolorizeInner(t *testing.T) {
 
 		inner, err := depolorizer.depolorizeInner()
 		assert.Nil(t, err)
-		assert.Equal(t, &Depolorizer{data: readbuffer{WireNull, []byte{}}}, inner)
+		assert.Equal(t, &Depolorizer{data: readbuffer{WireNull, []byte{}, nil}}, inner)
 
 		inner, err = depolorizer.depolorizeInner()
 		assert.Nil(t, err)
-		assert.Equal(t, &Depolorizer{data: readbuffer{WirePosInt, []byte{5}}}, inner)
+		assert.Equal(t, &Depolorizer{data: readbuffer{WirePosInt, []byte{5}, nil}}, inner)
 
 		_, err = depolorizer.depolorizeInner()
 		assert.EqualError(t, err, "insufficient data in wire for decode")
@@ -677,6 +678,125 @@ func TestDepolorizer_IsNull(t *testing.T) {
 	}
 }
 
+func TestDepolorizer_PeekSkip(t *testing.T) {
+	t.Run("Packed", func(t *testing.T) {
+		polorizer := NewPolorizer()
+		polorizer.PolorizeString("foo")
+		polorizer.PolorizeInt(300)
+		polorizer.PolorizeNull()
+		polorizer.PolorizeBool(true)
+
+		wire := polorizer.Packed()
+
+		depolorizer, err := NewDepolorizer(wire)
+		require.Nil(t, err)
+
+		depolorizer, err = depolorizer.Unpacked()
+		require.Nil(t, err)
+
+		expected := []WireType{WireWord, WirePosInt, WireNull, WireTrue}
+
+		for index, wiretype := range expected {
+			assert.Equal(t, index, depolorizer.Position())
+			assert.Equal(t, len(expected)-index, depolorizer.Remaining())
+
+			peek, ok := depolorizer.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, wiretype, peek)
+
+			require.Nil(t, depolorizer.Skip())
+		}
+
+		assert.Equal(t, len(expected), depolorizer.Position())
+		assert.Zero(t, depolorizer.Remaining())
+
+		peek, ok := depolorizer.Peek()
+		assert.False(t, ok)
+		assert.Equal(t, WireNull, peek)
+
+		assert.EqualError(t, depolorizer.Skip(), ErrInsufficientWire.Error())
+	})
+
+	t.Run("Empty Pack", func(t *testing.T) {
+		depolorizer, err := NewDepolorizer([]byte{14, 15})
+		require.Nil(t, err)
+
+		depolorizer, err = depolorizer.Unpacked()
+		require.Nil(t, err)
+
+		assert.Zero(t, depolorizer.Position())
+		assert.Zero(t, depolorizer.Remaining())
+
+		_, ok := depolorizer.Peek()
+		assert.False(t, ok)
+	})
+
+	t.Run("Atomic", func(t *testing.T) {
+		depolorizer, err := NewDepolorizer([]byte{3, 1, 44})
+		require.Nil(t, err)
+
+		assert.Zero(t, depolorizer.Position())
+		assert.Equal(t, 1, depolorizer.Remaining())
+
+		peek, ok := depolorizer.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, WirePosInt, peek)
+
+		require.Nil(t, depolorizer.Skip())
+
+		assert.Equal(t, 1, depolorizer.Position())
+		assert.Zero(t, depolorizer.Remaining())
+		assert.False(t, depolorizer.IsNull())
+
+		_, ok = depolorizer.Peek()
+		assert.False(t, ok)
+	})
+}
+
+func TestDepolorizer_Elements(t *testing.T) {
+	wire, err := Polorize([]string{"foo", "bar", "baz"})
+	require.Nil(t, err)
+
+	depolorizer, err := NewDepolorizer(wire)
+	require.Nil(t, err)
+
+	depolorizer, err = depolorizer.Unpacked()
+	require.Nil(t, err)
+
+	// Consume the first element before iterating
+	first, err := depolorizer.DepolorizeString()
+	require.Nil(t, err)
+	assert.Equal(t, "foo", first)
+
+	var (
+		positions []int
+		elements  []Any
+	)
+
+	for position, element := range depolorizer.Elements() {
+		positions = append(positions, position)
+		elements = append(elements, element)
+	}
+
+	assert.Equal(t, []int{1, 2}, positions)
+	assert.Equal(t, []Any{{6, 98, 97, 114}, {6, 98, 97, 122}}, elements)
+	assert.True(t, depolorizer.Done())
+
+	t.Run("Malformed", func(t *testing.T) {
+		depolorizer, err := NewDepolorizer([]byte{14, 47, 6, 134})
+		require.Nil(t, err)
+
+		depolorizer, err = depolorizer.Unpacked()
+		require.Nil(t, err)
+
+		for range depolorizer.Elements() {
+			t.Fatal("unexpected element")
+		}
+
+		assert.False(t, depolorizer.Done())
+	})
+}
+
 func TestInsufficientWire(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -713,3 +833,281 @@ func TestInsufficientWire(t *testing.T) {
 		})
 	}
 }
+
+func TestDepolorizer_ZeroCopy(t *testing.T) {
+	type Object struct {
+		A []byte
+		B string
+		C Raw
+		D Document
+		E []string
+	}
+
+	object := Object{
+		A: []byte{1, 2, 3},
+		B: "foo",
+		C: Raw{6, 98, 97, 114},
+		D: Document{"bar": Raw{3, 5}},
+		E: []string{"baz"},
+	}
+
+	// within returns whether the memory of s lies within the memory of wire
+	within := func(wire []byte, s []byte) bool {
+		start := uintptr(unsafe.Pointer(unsafe.SliceData(wire)))
+		ptr := uintptr(unsafe.Pointer(unsafe.SliceData(s)))
+
+		return ptr >= start && ptr < start+uintptr(len(wire))
+	}
+
+	wire, err := Polorize(object)
+	require.Nil(t, err)
+
+	t.Run("Aliased", func(t *testing.T) {
+		decoded := new(Object)
+		require.Nil(t, Depolorize(decoded, wire, ZeroCopy()))
+		require.Equal(t, object, *decoded)
+
+		assert.True(t, within(wire, decoded.A))
+		assert.True(t, within(wire, unsafe.Slice(unsafe.StringData(decoded.B), len(decoded.B))))
+		assert.True(t, within(wire, decoded.C))
+		assert.True(t, within(wire, decoded.D["bar"]))
+		assert.True(t, within(wire, unsafe.Slice(unsafe.StringData(decoded.E[0]), len(decoded.E[0]))))
+	})
+
+	t.Run("Copied", func(t *testing.T) {
+		decoded := new(Object)
+		require.Nil(t, Depolorize(decoded, wire))
+		require.Equal(t, object, *decoded)
+
+		assert.False(t, within(wire, decoded.A))
+		assert.False(t, within(wire, unsafe.Slice(unsafe.StringData(decoded.B), len(decoded.B))))
+		assert.False(t, within(wire, decoded.C))
+		assert.False(t, within(wire, decoded.D["bar"]))
+		assert.False(t, within(wire, unsafe.Slice(unsafe.StringData(decoded.E[0]), len(decoded.E[0]))))
+	})
+
+	t.Run("Any", func(t *testing.T) {
+		type Tagged struct {
+			Value Any `polo:"value"`
+		}
+
+		// Top-level wires have their tag contiguous with their data
+		anywire := []byte{6, 102, 111, 111}
+
+		var decoded Any
+		require.Nil(t, Depolorize(&decoded, anywire, ZeroCopy()))
+		require.Equal(t, Any(anywire), decoded)
+		assert.True(t, within(anywire, decoded))
+
+		// Document values are stored with their tags
+		docwire, err := Polorize(Tagged{Value: anywire}, DocStructs())
+		require.Nil(t, err)
+
+		tagged := new(Tagged)
+		require.Nil(t, Depolorize(tagged, docwire, DocStructs(), ZeroCopy()))
+		require.Equal(t, Any(anywire), tagged.Value)
+		assert.True(t, within(docwire, tagged.Value))
+
+		// Pack elements have their tags apart from their data
+		packwire, err := Polorize(Tagged{Value: anywire})
+		require.Nil(t, err)
+
+		tagged = new(Tagged)
+		require.Nil(t, Depolorize(tagged, packwire, ZeroCopy()))
+		require.Equal(t, Any(anywire), tagged.Value)
+		assert.False(t, within(packwire, tagged.Value))
+	})
+
+	t.Run("Custom Decoding", func(t *testing.T) {
+		depolorizer, err := NewDepolorizer([]byte{6, 102, 111, 111}, ZeroCopy())
+		require.Nil(t, err)
+
+		inner, err := depolorizer.depolorizeInner()
+		require.Nil(t, err)
+		assert.True(t, inner.cfg.zeroCopy)
+	})
+}
+
+func TestDepolorizer_UnknownFields(t *testing.T) {
+	type FruitV2 struct {
+		Name  string
+		Cost  int    `polo:"cost"`
+		Color string `polo:"color"`
+	}
+
+	type FruitV1 struct {
+		Name string
+		Cost int `polo:"cost"`
+	}
+
+	type FruitProxy struct {
+		Name  string
+		Extra Document `polo:",unknown"`
+	}
+
+	wire, err := Polorize(FruitV2{"orange", 300, "orange"}, DocStructs())
+	require.NoError(t, err)
+
+	t.Run("Ignored", func(t *testing.T) {
+		fruit := new(FruitV1)
+		require.NoError(t, Depolorize(fruit, wire, DocStructs()))
+		assert.Equal(t, FruitV1{"orange", 300}, *fruit)
+	})
+
+	t.Run("Disallowed", func(t *testing.T) {
+		fruit := new(FruitV1)
+		err := Depolorize(fruit, wire, DocStructs(), DisallowUnknownFields())
+		assert.EqualError(t, err, "incompatible wire: struct [polo.FruitV1]: unknown document keys [color]")
+
+		fruitV2 := new(FruitV2)
+		require.NoError(t, Depolorize(fruitV2, wire, DocStructs(), DisallowUnknownFields()))
+		assert.Equal(t, FruitV2{"orange", 300, "orange"}, *fruitV2)
+	})
+
+	t.Run("Kept", func(t *testing.T) {
+		proxy := new(FruitProxy)
+		require.NoError(t, Depolorize(proxy, wire, DocStructs(), DisallowUnknownFields()))
+		assert.Equal(t, "orange", proxy.Name)
+		assert.Equal(t, []string{"color", "cost"}, proxy.Extra.Keys())
+
+		// Encoding the proxy must reproduce the original wire
+		reencoded, err := Polorize(proxy, DocStructs())
+		require.NoError(t, err)
+		assert.Equal(t, wire, reencoded)
+
+		size, err := SizeOf(proxy, DocStructs())
+		require.NoError(t, err)
+		assert.Equal(t, len(wire), size)
+
+		// Struct fields take precedence over unknown keys
+		proxy.Extra.SetRaw("Name", Raw{6, 'l', 'i', 'm', 'e'})
+
+		reencoded, err = Polorize(proxy, DocStructs())
+		require.NoError(t, err)
+		assert.Equal(t, wire, reencoded)
+	})
+
+	t.Run("None", func(t *testing.T) {
+		wire, err := Polorize(FruitV1{"orange", 300}, DocStructs())
+		require.NoError(t, err)
+
+		type Proxy struct {
+			Name  string
+			Cost  int      `polo:"cost"`
+			Extra Document `polo:",unknown"`
+		}
+
+		proxy := new(Proxy)
+		require.NoError(t, Depolorize(proxy, wire, DocStructs()))
+		assert.Equal(t, Proxy{"orange", 300, nil}, *proxy)
+	})
+}
+
+func TestDepolorizer_KeyMatching(t *testing.T) {
+	type Person struct {
+		Name string `polo:"name,alias=Name|full_name"`
+		Age  int    `polo:"age"`
+	}
+
+	// Documents produced with different naming conventions
+	snake := make(Document)
+	require.NoError(t, snake.Set("full_name", "alice"))
+	require.NoError(t, snake.Set("AGE", 30))
+
+	t.Run("Alias", func(t *testing.T) {
+		person := new(Person)
+		require.NoError(t, Depolorize(person, snake.Bytes(), DocStructs()))
+		assert.Equal(t, Person{"alice", 0}, *person)
+
+		err := Depolorize(person, snake.Bytes(), DocStructs(), DisallowUnknownFields())
+		assert.EqualError(t, err, "incompatible wire: struct [polo.Person]: unknown document keys [AGE]")
+	})
+
+	t.Run("CaseInsensitive", func(t *testing.T) {
+		person := new(Person)
+		require.NoError(t, Depolorize(person, snake.Bytes(), DocStructs(), CaseInsensitiveKeys(), DisallowUnknownFields()))
+		assert.Equal(t, Person{"alice", 30}, *person)
+	})
+
+	t.Run("Canonical", func(t *testing.T) {
+		wire, err := Polorize(Person{"alice", 30}, DocStructs())
+		require.NoError(t, err)
+
+		doc := make(Document)
+		require.NoError(t, Depolorize(&doc, wire))
+		assert.Equal(t, []string{"age", "name"}, doc.Keys())
+	})
+}
+
+func TestDepolorizer_TrailingFields(t *testing.T) {
+	type FruitV1 struct {
+		Name string
+		Cost int
+	}
+
+	type FruitV2 struct {
+		Name  string
+		Cost  int
+		Color string `polo:",default=green"`
+		Sizes []int
+	}
+
+	type FruitProxy struct {
+		Name string
+		Rest []Any `polo:",rest"`
+		Cost int
+	}
+
+	v1, err := Polorize(FruitV1{"orange", 300})
+	require.NoError(t, err)
+
+	v2, err := Polorize(FruitV2{"orange", 300, "orange", []int{1, 2}})
+	require.NoError(t, err)
+
+	t.Run("Missing", func(t *testing.T) {
+		err := Depolorize(new(FruitV2), v1)
+		assert.EqualError(t, err, "incompatible wire: struct field [polo.FruitV2.Color <string>]: "+
+			"insufficient data in wire for decode")
+
+		fruit := new(FruitV2)
+		require.NoError(t, Depolorize(fruit, v1, AllowMissingFields()))
+		assert.Equal(t, FruitV2{"orange", 300, "green", nil}, *fruit)
+	})
+
+	t.Run("Trailing", func(t *testing.T) {
+		fruit := new(FruitV1)
+		require.NoError(t, Depolorize(fruit, v2))
+		assert.Equal(t, FruitV1{"orange", 300}, *fruit)
+
+		err := Depolorize(fruit, v2, DisallowTrailingElements())
+		assert.EqualError(t, err, "incompatible wire: struct [polo.FruitV1]: 2 unexpected trailing elements")
+
+		require.NoError(t, Depolorize(fruit, v1, DisallowTrailingElements()))
+	})
+
+	t.Run("Rest", func(t *testing.T) {
+		proxy := new(FruitProxy)
+		require.NoError(t, Depolorize(proxy, v2, DisallowTrailingElements()))
+		assert.Equal(t, "orange", proxy.Name)
+		assert.Equal(t, 300, proxy.Cost)
+		require.Len(t, proxy.Rest, 2)
+
+		var color string
+		require.NoError(t, Depolorize(&color, proxy.Rest[0]))
+		assert.Equal(t, "orange", color)
+
+		// Encoding the proxy must reproduce the original wire
+		reencoded, err := Polorize(proxy)
+		require.NoError(t, err)
+		assert.Equal(t, v2, reencoded)
+
+		size, err := SizeOf(proxy)
+		require.NoError(t, err)
+		assert.Equal(t, len(v2), size)
+
+		// No trailing elements leave the rest field as nil
+		proxy = new(FruitProxy)
+		require.NoError(t, Depolorize(proxy, v1))
+		assert.Equal(t, FruitProxy{"orange", nil, 300}, *proxy)
+	})
+}
@@ -0,0 +1,251 @@
+package polo
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// pathStep is a single step of a document path, which is either a document key or a pack index.
+type pathStep struct {
+	key   string
+	index int // represents the pack index for the step, -1 for document key steps
+	end   int // represents the end position of the step in the path
+}
+
+// parsePath parses a document path into its steps. A path is a sequence of document keys separated by
+// dots, each of which can be followed by any number of pack indices in brackets, e.g, "inputs[2].amount".
+// Returns an error if the path is empty or malformed.
+func parsePath(path string) ([]pathStep, error) {
+	return parseSteps(path, true)
+}
+
+// parseSteps parses a path into its steps. If keyed is false, the path is allowed
+// to start with a pack index instead of a document key, e.g, "[1].amount".
+func parseSteps(path string, keyed bool) ([]pathStep, error) {
+	steps := make([]pathStep, 0, strings.Count(path, ".")+1)
+
+	// offset represents the start position of the current segment in the path
+	offset := 0
+
+	for _, segment := range strings.Split(path, ".") {
+		// The key for the segment is everything before the first index.
+		// It can only be empty for the first segment of an unkeyed path.
+		key, _, _ := strings.Cut(segment, "[")
+
+		switch {
+		case key != "":
+			offset += len(key)
+			steps = append(steps, pathStep{key: key, index: -1, end: offset})
+		case keyed || offset != 0 || segment == "":
+			return nil, fmt.Errorf("malformed document path '%v': empty key at position %v", path, offset)
+		}
+
+		// Parse each index that follows the key
+		for rest := segment[len(key):]; rest != ""; {
+			// Every index must be immediately followed by another index
+			if rest[0] != '[' {
+				return nil, fmt.Errorf("malformed document path '%v': unexpected '%v' after index", path, rest)
+			}
+
+			index, after, ok := strings.Cut(rest[1:], "]")
+			if !ok {
+				return nil, fmt.Errorf("malformed document path '%v': unterminated index at position %v", path, offset)
+			}
+
+			value, err := strconv.Atoi(index)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("malformed document path '%v': invalid index '%v'", path, index)
+			}
+
+			// Account for the brackets around the index
+			offset += len(index) + 2
+			steps = append(steps, pathStep{index: value, end: offset})
+
+			rest = after
+		}
+
+		// Account for the separating dot
+		offset++
+	}
+
+	return steps, nil
+}
+
+// pathElements decodes the wire of a value along a document path into the wires of its pack elements.
+func pathElements(wire []byte) ([]Any, error) {
+	depolorizer, err := NewDepolorizer(wire)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only packs can be indexed, documents must be accessed with keys
+	if wiretype, _ := depolorizer.Peek(); wiretype != WirePack {
+		return nil, IncompatibleWireType(wiretype, WirePack)
+	}
+
+	if depolorizer, err = depolorizer.Unpacked(); err != nil {
+		return nil, err
+	}
+
+	elements := make([]Any, 0, depolorizer.Remaining())
+	for !depolorizer.Done() {
+		element, err := depolorizer.DepolorizeAny()
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, element)
+	}
+
+	return elements, nil
+}
+
+// GetPath retrieves some object for some given path from a Document, descending through nested documents
+// and packs along the path. The path is a sequence of keys separated by dots, each of which can be followed
+// by pack indices in brackets, e.g, "header.signer.address" or "inputs[2].amount".
+// The data at the path is decoded from its POLO form into the given object which must be a pointer.
+// Accepts EncodingOptions to modify the decoding behaviour of the object.
+// Returns an error if the path is malformed, there is no data at the path or if the data could not be decoded.
+func (doc Document) GetPath(path string, object any, options ...EncodingOptions) error {
+	steps, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+
+	// The first step is always a key of the Document
+	wire := []byte(doc.GetRaw(steps[0].key))
+	if wire == nil {
+		return fmt.Errorf("document value not found for path '%v'", path[:steps[0].end])
+	}
+
+	for i, step := range steps[1:] {
+		// parent represents the path to the value being descended into
+		parent := path[:steps[i].end]
+
+		// Descend into a pack element
+		if step.index >= 0 {
+			elements, err := pathElements(wire)
+			if err != nil {
+				return fmt.Errorf("document value at path '%v' could not be indexed: %w", parent, err)
+			}
+
+			if step.index >= len(elements) {
+				return fmt.Errorf("document value not found for path '%v': index out of range", path[:step.end])
+			}
+
+			wire = elements[step.index]
+
+			continue
+		}
+
+		// Descend into a document value
+		document, err := decodeDocumentRaw(wire)
+		if err != nil {
+			return fmt.Errorf("document value at path '%v' is not a document: %w", parent, err)
+		}
+
+		if wire = document.GetRaw(step.key); wire == nil {
+			return fmt.Errorf("document value not found for path '%v'", path[:step.end])
+		}
+	}
+
+	// Depolorize the data into the given object and return any error
+	if err = Depolorize(object, wire, options...); err != nil {
+		return fmt.Errorf("document value could not be decoded for path '%v': %w", path, err)
+	}
+
+	return nil
+}
+
+// SetPath inserts some object for some given path into a Document, descending through nested documents
+// and packs along the path. The path has the same format as for GetPath. The given object is encoded into
+// its POLO form and inserted, overwriting any existing data, after which every document and pack along the
+// path is re-encoded. Documents along the path that do not exist are created, but packs must already
+// contain the indexed elements. Returns an error if the path is malformed, cannot be descended into
+// or if the given object cannot be serialized with Polorize().
+func (doc Document) SetPath(path string, object any, options ...EncodingOptions) error {
+	steps, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+
+	// Polorize the object into its wire form. Return any error that occurs
+	data, err := Polorize(object, append(slices.Clip(options), uncompressed())...)
+	if err != nil {
+		return fmt.Errorf("document value could not be encoded for path '%v': %w", path, err)
+	}
+
+	// Insert the wire data into the value for the first key
+	updated, err := setPathValue(doc.GetRaw(steps[0].key), path, steps, data)
+	if err != nil {
+		return err
+	}
+
+	doc.SetRaw(steps[0].key, updated)
+
+	return nil
+}
+
+// setPathValue inserts data into the wire of the value for the first of the given
+// steps along the rest of the steps and returns the updated wire for the value.
+func setPathValue(wire []byte, path string, steps []pathStep, data []byte) ([]byte, error) {
+	// The value for the last step is replaced with the data
+	if len(steps) == 1 {
+		return data, nil
+	}
+
+	// parent represents the path to the value being descended into
+	parent, step := path[:steps[0].end], steps[1]
+
+	// Descend into a pack element
+	if step.index >= 0 {
+		elements, err := pathElements(wire)
+		if err != nil {
+			return nil, fmt.Errorf("document value at path '%v' could not be indexed: %w", parent, err)
+		}
+
+		if step.index >= len(elements) {
+			return nil, fmt.Errorf("document value not found for path '%v': index out of range", path[:step.end])
+		}
+
+		updated, err := setPathValue(elements[step.index], path, steps[1:], data)
+		if err != nil {
+			return nil, err
+		}
+
+		elements[step.index] = updated
+
+		// Re-encode the pack with the updated element
+		pack := NewPolorizer()
+		for _, element := range elements {
+			if err = pack.PolorizeAny(element); err != nil {
+				return nil, err
+			}
+		}
+
+		return pack.Packed(), nil
+	}
+
+	// Descend into a document value
+	document, err := decodeDocumentRaw(wire)
+	if err != nil {
+		return nil, fmt.Errorf("document value at path '%v' is not a document: %w", parent, err)
+	}
+
+	// Create the document if it does not exist
+	if document == nil {
+		document = make(Document)
+	}
+
+	updated, err := setPathValue(document.GetRaw(step.key), path, steps[1:], data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-encode the document with the updated value
+	document.SetRaw(step.key, updated)
+
+	return document.Bytes(), nil
+}
@@ -0,0 +1,163 @@
+package polo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path  string
+		steps []pathStep
+		err   string
+	}{
+		{"foo", []pathStep{{"foo", -1, 3}}, ""},
+		{"foo.bar", []pathStep{{"foo", -1, 3}, {"bar", -1, 7}}, ""},
+		{"foo[2].bar", []pathStep{{"foo", -1, 3}, {"", 2, 6}, {"bar", -1, 10}}, ""},
+		{"foo[1][10]", []pathStep{{"foo", -1, 3}, {"", 1, 6}, {"", 10, 10}}, ""},
+		{"", nil, "malformed document path '': empty key at position 0"},
+		{"foo..bar", nil, "malformed document path 'foo..bar': empty key at position 4"},
+		{"foo.[1]", nil, "malformed document path 'foo.[1]': empty key at position 4"},
+		{"foo[1", nil, "malformed document path 'foo[1': unterminated index at position 3"},
+		{"foo[", nil, "malformed document path 'foo[': unterminated index at position 3"},
+		{"foo[a]", nil, "malformed document path 'foo[a]': invalid index 'a'"},
+		{"foo[-1]", nil, "malformed document path 'foo[-1]': invalid index '-1'"},
+		{"foo[1]bar", nil, "malformed document path 'foo[1]bar': unexpected 'bar' after index"},
+		{"[1].foo", nil, "malformed document path '[1].foo': empty key at position 0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			steps, err := parsePath(test.path)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.steps, steps)
+		})
+	}
+
+	t.Run("Unkeyed", func(t *testing.T) {
+		steps, err := parseSteps("[1][0].foo", false)
+		require.Nil(t, err)
+		assert.Equal(t, []pathStep{{"", 1, 3}, {"", 0, 6}, {"foo", -1, 10}}, steps)
+
+		_, err = parseSteps("", false)
+		assert.EqualError(t, err, "malformed document path '': empty key at position 0")
+
+		_, err = parseSteps("[1].[2]", false)
+		assert.EqualError(t, err, "malformed document path '[1].[2]': empty key at position 4")
+	})
+}
+
+type PathSigner struct {
+	Address string
+	Nonce   uint64
+}
+
+type PathInput struct {
+	Asset  string
+	Amount uint64
+}
+
+type PathObject struct {
+	Header struct {
+		Height uint64
+		Signer PathSigner
+	}
+	Inputs []PathInput
+	Matrix [][]string
+}
+
+func newPathDocument(t *testing.T) Document {
+	t.Helper()
+
+	object := PathObject{
+		Inputs: []PathInput{{"foo", 100}, {"bar", 200}, {"baz", 300}},
+		Matrix: [][]string{{"a", "b"}, {"c", "d"}},
+	}
+
+	object.Header.Height = 42
+	object.Header.Signer = PathSigner{"0xabc", 7}
+
+	doc, err := PolorizeDocument(object, DocStructs())
+	require.Nil(t, err)
+
+	return doc
+}
+
+func TestDocument_GetPath(t *testing.T) {
+	doc := newPathDocument(t)
+
+	var address string
+	require.Nil(t, doc.GetPath("Header.Signer.Address", &address))
+	assert.Equal(t, "0xabc", address)
+
+	var signer PathSigner
+	require.Nil(t, doc.GetPath("Header.Signer", &signer, DocStructs()))
+	assert.Equal(t, PathSigner{"0xabc", 7}, signer)
+
+	var amount uint64
+	require.Nil(t, doc.GetPath("Inputs[2].Amount", &amount))
+	assert.Equal(t, uint64(300), amount)
+
+	var cell string
+	require.Nil(t, doc.GetPath("Matrix[1][0]", &cell))
+	assert.Equal(t, "c", cell)
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			path string
+			err  string
+		}{
+			{"Footer", "document value not found for path 'Footer'"},
+			{"Header.Signer.Key", "document value not found for path 'Header.Signer.Key'"},
+			{"Inputs[3].Amount", "document value not found for path 'Inputs[3]': index out of range"},
+			{"Header[0]", "document value at path 'Header' could not be indexed: incompatible wire: unexpected wiretype 'document'. expected one of: {pack}"},                 //nolint:lll
+			{"Inputs.Amount", "document value at path 'Inputs' is not a document: incompatible wire: unexpected wiretype 'pack'. expected one of: {null, document}"},          //nolint:lll
+			{"Header.Height", "document value could not be decoded for path 'Header.Height': incompatible wire: unexpected wiretype 'posint'. expected one of: {null, word}"}, //nolint:lll
+			{"Header..Height", "malformed document path 'Header..Height': empty key at position 7"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.path, func(t *testing.T) {
+				var value string
+				assert.EqualError(t, doc.GetPath(test.path, &value), test.err)
+			})
+		}
+	})
+}
+
+func TestDocument_SetPath(t *testing.T) {
+	doc := newPathDocument(t)
+
+	require.Nil(t, doc.SetPath("Header.Signer.Address", "0xdef"))
+	require.Nil(t, doc.SetPath("Inputs[1].Amount", uint64(250)))
+	require.Nil(t, doc.SetPath("Matrix[0][1]", "e"))
+	require.Nil(t, doc.SetPath("Header.Extra.Tag", "new"))
+
+	object := new(PathObject)
+	require.Nil(t, Depolorize(object, doc.Bytes(), DocStructs()))
+
+	assert.Equal(t, "0xdef", object.Header.Signer.Address)
+	assert.Equal(t, uint64(7), object.Header.Signer.Nonce)
+	assert.Equal(t, []PathInput{{"foo", 100}, {"bar", 250}, {"baz", 300}}, object.Inputs)
+	assert.Equal(t, [][]string{{"a", "e"}, {"c", "d"}}, object.Matrix)
+
+	var tag string
+	require.Nil(t, doc.GetPath("Header.Extra.Tag", &tag))
+	assert.Equal(t, "new", tag)
+
+	t.Run("Errors", func(t *testing.T) {
+		assert.EqualError(t, doc.SetPath("Inputs[5].Amount", 1),
+			"document value not found for path 'Inputs[5]': index out of range")
+		assert.EqualError(t, doc.SetPath("Header.Height.Value", 1),
+			"document value at path 'Header.Height' is not a document: incompatible wire: unexpected wiretype 'posint'. expected one of: {null, document}") //nolint:lll
+		assert.EqualError(t, doc.SetPath("Header.Height", make(chan int)),
+			"document value could not be encoded for path 'Header.Height': incompatible value error: unsupported type: chan int [chan]") //nolint:lll
+	})
+}
@@ -0,0 +1,110 @@
+package polo
+
+import "slices"
+
+// DocSchema is a declaration of the expected keys of a Document and the wire types of their values.
+// It can be used to validate a Document with Document.Validate or while decoding with ValidateSchema.
+//
+//	schema := polo.DocSchema{
+//		"name": polo.Required(polo.WireWord),
+//		"age":  polo.Optional(polo.WirePosInt),
+//	}
+//
+// Schemas where every key is required with a single wire type can be declared with NewDocSchema.
+type DocSchema map[string]SchemaField
+
+// NewDocSchema returns a DocSchema where each of the given keys is required
+// in the Document with a value that has the wire type of that key.
+//
+//	schema := polo.NewDocSchema(map[string]polo.WireType{"name": polo.WireWord, "age": polo.WirePosInt})
+func NewDocSchema(wires map[string]WireType) DocSchema {
+	schema := make(DocSchema, len(wires))
+	for key, wire := range wires {
+		schema[key] = Required(wire)
+	}
+
+	return schema
+}
+
+// SchemaField is the expectation of a DocSchema for the value of a single key.
+type SchemaField struct {
+	// Wires is the set of wire types that the value is allowed to have
+	Wires []WireType
+	// Optional indicates whether the key can be absent from the Document.
+	// Optional values are also allowed to be WireNull, regardless of Wires.
+	Optional bool
+}
+
+// Required returns a SchemaField for a key that must be present
+// in the Document with a value that has one of the given wire types.
+func Required(wires ...WireType) SchemaField {
+	return SchemaField{Wires: wires}
+}
+
+// Optional returns a SchemaField for a key that can be absent from the Document
+// but must have a value that has one of the given wire types (or WireNull) if present.
+func Optional(wires ...WireType) SchemaField {
+	return SchemaField{Wires: wires, Optional: true}
+}
+
+// Validate checks a Document against the given DocSchema.
+// Returns a SchemaError that reports every missing, unexpected and mistyped key
+// of the Document together, or nil if the Document conforms to the schema.
+func (doc Document) Validate(schema DocSchema) error {
+	var violations SchemaError
+
+	// Check the keys in the schema against the document
+	for _, key := range sortedKeys(schema) {
+		field := schema[key]
+
+		data, ok := doc[key]
+		if !ok {
+			if !field.Optional {
+				violations.Missing = append(violations.Missing, key)
+			}
+
+			continue
+		}
+
+		// Determine the wire type of the value, an empty value is considered to be null
+		wiretype := WireNull
+		if len(data) != 0 {
+			wiretype = WireType(data[0] & 15)
+		}
+
+		if slices.Contains(field.Wires, wiretype) || (field.Optional && wiretype == WireNull) {
+			continue
+		}
+
+		if violations.Mistyped == nil {
+			violations.Mistyped = make(map[string]IncompatibleWireError)
+		}
+
+		violations.Mistyped[key] = IncompatibleWireType(wiretype, field.Wires...)
+	}
+
+	// Check the keys in the document against the schema
+	for _, key := range doc.Keys() {
+		if _, ok := schema[key]; !ok {
+			violations.Unexpected = append(violations.Unexpected, key)
+		}
+	}
+
+	if len(violations.Missing) == 0 && len(violations.Unexpected) == 0 && len(violations.Mistyped) == 0 {
+		return nil
+	}
+
+	return violations
+}
+
+// sortedKeys returns the keys of a string keyed map in sorted order
+func sortedKeys[V any](mapping map[string]V) []string {
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
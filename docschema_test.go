@@ -0,0 +1,115 @@
+package polo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Validate(t *testing.T) {
+	schema := DocSchema{
+		"name":  Required(WireWord),
+		"age":   Optional(WirePosInt),
+		"score": Required(WirePosInt, WireNegInt),
+	}
+
+	t.Run("Conforming", func(t *testing.T) {
+		doc := make(Document)
+		require.NoError(t, doc.Set("name", "orange"))
+		require.NoError(t, doc.Set("score", -30))
+
+		assert.NoError(t, doc.Validate(schema))
+
+		// Optional values can be null
+		doc.SetRaw("age", Raw{0})
+		assert.NoError(t, doc.Validate(schema))
+	})
+
+	t.Run("Violations", func(t *testing.T) {
+		doc := make(Document)
+		require.NoError(t, doc.Set("age", "forty"))
+		doc.SetRaw("score", Raw{0})
+		require.NoError(t, doc.Set("color", "red"))
+		require.NoError(t, doc.Set("alias", "tangerine"))
+
+		err := doc.Validate(schema)
+		require.Error(t, err)
+
+		violations, ok := err.(SchemaError) //nolint:errorlint
+		require.True(t, ok)
+
+		assert.Equal(t, []string{"name"}, violations.Missing)
+		assert.Equal(t, []string{"alias", "color"}, violations.Unexpected)
+		assert.Equal(t, map[string]IncompatibleWireError{
+			"age":   IncompatibleWireType(WireWord, WirePosInt),
+			"score": IncompatibleWireType(WireNull, WirePosInt, WireNegInt),
+		}, violations.Mistyped)
+
+		assert.EqualError(t, err, "schema violation: "+
+			"missing keys [name], "+
+			"unexpected keys [alias, color], "+
+			"mistyped keys [age: unexpected wiretype 'word'. expected one of: {posint}; "+
+			"score: unexpected wiretype 'null'. expected one of: {posint, negint}]")
+	})
+}
+
+func TestNewDocSchema(t *testing.T) {
+	schema := NewDocSchema(map[string]WireType{"name": WireWord, "age": WirePosInt})
+	assert.Equal(t, DocSchema{"name": Required(WireWord), "age": Required(WirePosInt)}, schema)
+
+	doc := make(Document)
+	require.NoError(t, doc.Set("name", "orange"))
+	require.NoError(t, doc.Set("age", 30))
+	assert.NoError(t, doc.Validate(schema))
+
+	// Every key of the schema is required
+	doc.Delete("age")
+	assert.EqualError(t, doc.Validate(schema), "schema violation: missing keys [age]")
+}
+
+func TestValidateSchema(t *testing.T) {
+	type Fruit struct {
+		Name  string
+		Cost  int
+		Alias []string
+	}
+
+	wire, err := Polorize(Fruit{"orange", 300, []string{"tangerine"}}, DocStructs())
+	require.NoError(t, err)
+
+	t.Run("Conforming", func(t *testing.T) {
+		schema := DocSchema{
+			"Name":  Required(WireWord),
+			"Cost":  Required(WirePosInt),
+			"Alias": Optional(WirePack),
+		}
+
+		fruit := new(Fruit)
+		require.NoError(t, Depolorize(fruit, wire, DocStructs(), ValidateSchema(schema)))
+		assert.Equal(t, Fruit{"orange", 300, []string{"tangerine"}}, *fruit)
+	})
+
+	t.Run("Violating", func(t *testing.T) {
+		schema := DocSchema{
+			"Name": Required(WireWord),
+			"Cost": Required(WireWord),
+		}
+
+		fruit := new(Fruit)
+		err = Depolorize(fruit, wire, DocStructs(), ValidateSchema(schema))
+		assert.EqualError(t, err, "schema violation: "+
+			"unexpected keys [Alias], "+
+			"mistyped keys [Cost: unexpected wiretype 'posint'. expected one of: {word}]")
+		assert.Equal(t, Fruit{}, *fruit)
+	})
+
+	t.Run("Not Document", func(t *testing.T) {
+		wire, err := Polorize("orange")
+		require.NoError(t, err)
+
+		var name string
+		err = Depolorize(&name, wire, ValidateSchema(DocSchema{}))
+		assert.EqualError(t, err, "incompatible wire: unexpected wiretype 'word'. expected one of: {document}")
+	})
+}
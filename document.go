@@ -1,9 +1,13 @@
 package polo
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"iter"
 	"reflect"
+	"slices"
+	"sort"
 )
 
 // PolorizeDocument encodes an object into its POLO bytes such that it can be decoded as a Document.
@@ -63,6 +67,164 @@ func (doc Document) Bytes() []byte {
 	return polorizer.Bytes()
 }
 
+// Keys returns the keys of a Document in sorted order, which is the order in which they are encoded.
+func (doc Document) Keys() []string {
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+
+	// Sort the document keys
+	sort.Strings(keys)
+
+	return keys
+}
+
+// All returns an iterator over the keys and raw values of a Document.
+// The keys are iterated in sorted order, which is the order in which they are encoded.
+func (doc Document) All() iter.Seq2[string, Raw] {
+	return func(yield func(string, Raw) bool) {
+		for _, key := range doc.Keys() {
+			if !yield(key, doc[key]) {
+				return
+			}
+		}
+	}
+}
+
+// Has returns whether a Document has some data for a given key.
+func (doc Document) Has(key string) bool {
+	_, ok := doc[key]
+	return ok //nolint:nlreturn
+}
+
+// Delete removes the data for a given key from a Document.
+// It is a no-op if there is no data for the key.
+func (doc Document) Delete(key string) {
+	delete(doc, key)
+}
+
+// Equal returns whether two Document objects have the same keys with the same raw data for each key.
+// Equal Document objects have the same POLO wire representation.
+func (doc Document) Equal(other Document) bool {
+	if len(doc) != len(other) {
+		return false
+	}
+
+	for key, val := range doc {
+		otherVal, ok := other[key]
+		if !ok || !bytes.Equal(val, otherVal) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a deep copy of a Document. The raw data for each key is also copied.
+// Returns nil if the Document is nil.
+func (doc Document) Clone() Document {
+	if doc == nil {
+		return nil
+	}
+
+	clone := make(Document, len(doc))
+	for key, val := range doc {
+		clone[key] = bytes.Clone(val)
+	}
+
+	return clone
+}
+
+// MergeStrategy represents the strategy used by Document.Merge
+// to resolve keys that are present in both Document objects.
+type MergeStrategy int
+
+const (
+	// MergeOverwrite is a MergeStrategy that overwrites existing data with the data from the other Document
+	MergeOverwrite MergeStrategy = iota
+	// MergeKeepExisting is a MergeStrategy that keeps existing data and ignores the data from the other Document
+	MergeKeepExisting
+	// MergeRecursive is a MergeStrategy that recursively merges the data for a key if it is a
+	// document in both Document objects and overwrites existing data with the data from the
+	// other Document otherwise. Nested documents are merged with the same strategy.
+	MergeRecursive
+)
+
+// Merge inserts the data for each key from another Document into a Document. The data for keys
+// that are present in both Document objects is resolved according to the given MergeStrategy.
+// Returns an error if the strategy is unknown or if a nested document could not be merged,
+// in which case the Document is left unmodified.
+func (doc Document) Merge(other Document, strategy MergeStrategy) error {
+	switch strategy {
+	case MergeOverwrite, MergeKeepExisting, MergeRecursive:
+	default:
+		return fmt.Errorf("unknown merge strategy: %v", strategy)
+	}
+
+	// Collect the merged data for each key before inserting any of it,
+	// so that the Document is not partially merged if an error occurs
+	merged := make(Document, len(other))
+
+	for key, val := range other {
+		existing, ok := doc[key]
+
+		switch {
+		// Insert data for keys that are not in the Document regardless of strategy
+		case !ok, strategy == MergeOverwrite:
+			merged.SetRaw(key, val)
+
+		case strategy == MergeKeepExisting:
+			continue
+
+		case strategy == MergeRecursive:
+			// Overwrite the existing data unless both values are documents
+			if !IsWireType(existing, WireDoc) || !IsWireType(val, WireDoc) {
+				merged.SetRaw(key, val)
+				continue
+			}
+
+			// Decode both documents and merge them recursively
+			nested, err := decodeDocumentRaw(existing)
+			if err != nil {
+				return fmt.Errorf("document value could not be merged for key '%v': %w", key, err)
+			}
+
+			otherNested, err := decodeDocumentRaw(val)
+			if err != nil {
+				return fmt.Errorf("document value could not be merged for key '%v': %w", key, err)
+			}
+
+			if err = nested.Merge(otherNested, strategy); err != nil {
+				return fmt.Errorf("document value could not be merged for key '%v': %w", key, err)
+			}
+
+			merged.SetRaw(key, nested.Bytes())
+		}
+	}
+
+	for key, val := range merged {
+		doc.SetRaw(key, val)
+	}
+
+	return nil
+}
+
+// decodeDocumentRaw decodes some raw POLO data into a Document.
+// Returns a nil Document if the data is nil or a WireNull.
+func decodeDocumentRaw(data Raw) (Document, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	depolorizer, err := NewDepolorizer(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return depolorizer.DepolorizeDocument()
+}
+
 // GetRaw retrieves some raw byte data for a given key from a Document.
 // Return nil if there is no data for the key.
 func (doc Document) GetRaw(key string) Raw {
@@ -98,7 +260,7 @@ func (doc Document) Get(key string, object any) error {
 // Returns an error if the given object cannot be serialized with Polorize().
 func (doc Document) Set(key string, object any, options ...EncodingOptions) error {
 	// Polorize the object into its wire form. Return any error that occurs
-	data, err := Polorize(object, options...)
+	data, err := Polorize(object, append(slices.Clip(options), uncompressed())...)
 	if err != nil {
 		return fmt.Errorf("document value could not be encoded for key '%v': %w", key, err)
 	}
@@ -108,3 +270,23 @@ func (doc Document) Set(key string, object any, options ...EncodingOptions) erro
 
 	return nil
 }
+
+// DocGet retrieves some object of type T for some given key from a Document.
+// The data for the given key is decoded from its POLO form into an object of type T and returned.
+// Accepts EncodingOptions to modify the decoding behaviour.
+// Returns an error if there is no data for the key or if the data could not be decoded into T.
+func DocGet[T any](doc Document, key string, options ...EncodingOptions) (T, error) {
+	// Retrieve the data for the key and error if unavailable
+	data := doc.GetRaw(key)
+	if data == nil {
+		return *new(T), fmt.Errorf("document value not found for key '%v'", key)
+	}
+
+	// Decode the data into an object of type T and return any error
+	object, err := Decode[T](data, options...)
+	if err != nil {
+		return object, fmt.Errorf("document value could not be decoded for key '%v': %w", key, err)
+	}
+
+	return object, nil
+}
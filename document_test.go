@@ -184,6 +184,154 @@ func TestDocument_GetSetRaw(t *testing.T) {
 	assert.Equal(t, Raw{2, 1, 2, 1}, doc.GetRaw("bar"))
 }
 
+func TestDocument_All(t *testing.T) {
+	doc := Document{"foo": Raw{3, 25}, "bar": Raw{6, 104, 105}, "baz": Raw{0}}
+
+	var (
+		keys   []string
+		values []Raw
+	)
+
+	for key, value := range doc.All() {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	assert.Equal(t, []string{"bar", "baz", "foo"}, keys)
+	assert.Equal(t, []Raw{{6, 104, 105}, {0}, {3, 25}}, values)
+
+	// Break out of the iteration early
+	for key := range doc.All() {
+		assert.Equal(t, "bar", key)
+		break
+	}
+}
+
+func TestDocument_Keys(t *testing.T) {
+	doc := Document{"foo": Raw{0}, "bar": Raw{0}, "baz": Raw{0}}
+	assert.Equal(t, []string{"bar", "baz", "foo"}, doc.Keys())
+	assert.Equal(t, []string{}, Document{}.Keys())
+}
+
+func TestDocument_HasDelete(t *testing.T) {
+	doc := Document{"foo": Raw{3, 25}, "bar": nil}
+
+	assert.True(t, doc.Has("foo"))
+	assert.True(t, doc.Has("bar"))
+	assert.False(t, doc.Has("baz"))
+
+	doc.Delete("foo")
+	doc.Delete("baz")
+
+	assert.False(t, doc.Has("foo"))
+	assert.Equal(t, 1, doc.Size())
+}
+
+func TestDocument_EqualClone(t *testing.T) {
+	doc := Document{"foo": Raw{3, 25}, "bar": Raw{6, 104, 105}}
+
+	clone := doc.Clone()
+	assert.True(t, doc.Equal(clone))
+	assert.Equal(t, doc.Bytes(), clone.Bytes())
+
+	// Modifying the clone must not modify the original
+	clone["foo"][1] = 26
+	assert.False(t, doc.Equal(clone))
+	assert.Equal(t, Raw{3, 25}, doc["foo"])
+
+	assert.False(t, doc.Equal(Document{"foo": Raw{3, 25}}))
+	assert.False(t, doc.Equal(Document{"foo": Raw{3, 25}, "baz": Raw{6, 104, 105}}))
+	assert.True(t, Document{}.Equal(nil))
+	assert.Nil(t, Document(nil).Clone())
+}
+
+func TestDocument_Merge(t *testing.T) {
+	newDocument := func(t *testing.T, values map[string]any) Document {
+		t.Helper()
+
+		doc := make(Document)
+		for key, value := range values {
+			require.Nil(t, doc.Set(key, value))
+		}
+
+		return doc
+	}
+
+	nested := newDocument(t, map[string]any{"a": 1, "b": 2})
+	otherNested := newDocument(t, map[string]any{"b": 3, "c": 4})
+
+	tests := []struct {
+		name     string
+		strategy MergeStrategy
+		expected Document
+	}{
+		{
+			"Overwrite", MergeOverwrite,
+			newDocument(t, map[string]any{"foo": "other", "bar": "bar", "baz": "baz", "doc": otherNested}),
+		},
+		{
+			"Keep Existing", MergeKeepExisting,
+			newDocument(t, map[string]any{"foo": "foo", "bar": "bar", "baz": "baz", "doc": nested}),
+		},
+		{
+			"Recursive", MergeRecursive,
+			newDocument(t, map[string]any{
+				"foo": "other", "bar": "bar", "baz": "baz",
+				"doc": newDocument(t, map[string]any{"a": 1, "b": 3, "c": 4}),
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc := newDocument(t, map[string]any{"foo": "foo", "bar": "bar", "doc": nested})
+			other := newDocument(t, map[string]any{"foo": "other", "baz": "baz", "doc": otherNested})
+
+			require.Nil(t, doc.Merge(other, test.strategy))
+			assert.True(t, test.expected.Equal(doc))
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		doc := newDocument(t, map[string]any{"foo": "foo"})
+
+		err := doc.Merge(newDocument(t, map[string]any{"foo": "bar"}), MergeStrategy(5))
+		assert.EqualError(t, err, "unknown merge strategy: 5")
+
+		// Unknown strategies are rejected even if no keys collide
+		err = doc.Merge(newDocument(t, map[string]any{"bar": "bar"}), MergeStrategy(5))
+		assert.EqualError(t, err, "unknown merge strategy: 5")
+		assert.True(t, newDocument(t, map[string]any{"foo": "foo"}).Equal(doc))
+
+		doc = Document{"doc": Raw{13, 47, 6, 134}}
+		err = doc.Merge(Document{"doc": nested.Bytes(), "foo": Raw{6, 98}}, MergeRecursive)
+		assert.EqualError(t, err, "document value could not be merged for key 'doc': malformed tag: varint terminated prematurely")
+
+		// Failed merges leave the Document unmodified
+		assert.True(t, Document{"doc": Raw{13, 47, 6, 134}}.Equal(doc))
+	})
+}
+
+func TestDocGet(t *testing.T) {
+	doc := make(Document)
+	require.Nil(t, doc.Set("foo", 25))
+	require.Nil(t, doc.Set("bar", "hello"))
+
+	foo, err := DocGet[int](doc, "foo")
+	require.Nil(t, err)
+	assert.Equal(t, 25, foo)
+
+	bar, err := DocGet[string](doc, "bar")
+	require.Nil(t, err)
+	assert.Equal(t, "hello", bar)
+
+	_, err = DocGet[int](doc, "far")
+	assert.EqualError(t, err, "document value not found for key 'far'")
+
+	_, err = DocGet[int](doc, "bar")
+	assert.EqualError(t, err, "document value could not be decoded for key 'bar': incompatible wire: unexpected wiretype 'word'. expected one of: {null, posint, negint}") //nolint:lll
+}
+
 func TestDocument_Set(t *testing.T) {
 	// Create a Document
 	doc := make(Document)
@@ -0,0 +1,193 @@
+package polo
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"slices"
+)
+
+// SignatureAlgorithm is an identifier for the signature algorithm of an Envelope
+type SignatureAlgorithm uint8
+
+// SignatureAlgorithm enums
+const (
+	// SignatureEd25519 is an Ed25519 signature over the payload
+	SignatureEd25519 SignatureAlgorithm = iota + 1
+	// SignatureECDSAP256 is an ASN.1 encoded ECDSA signature with the P-256 curve over the SHA-256 digest of the payload
+	SignatureECDSAP256
+	// SignatureECDSAP384 is an ASN.1 encoded ECDSA signature with the P-384 curve over the SHA-384 digest of the payload
+	SignatureECDSAP384
+	// SignatureECDSAP521 is an ASN.1 encoded ECDSA signature with the P-521 curve over the SHA-512 digest of the payload
+	SignatureECDSAP521
+)
+
+// String implements the Stringer interface for SignatureAlgorithm
+func (algorithm SignatureAlgorithm) String() string {
+	switch algorithm {
+	case SignatureEd25519:
+		return "ed25519"
+	case SignatureECDSAP256:
+		return "ecdsa-p256"
+	case SignatureECDSAP384:
+		return "ecdsa-p384"
+	case SignatureECDSAP521:
+		return "ecdsa-p521"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(algorithm))
+	}
+}
+
+// hash returns the hash function used to digest the payload for the algorithm.
+// Ed25519 signs the payload directly and returns a zero hash.
+func (algorithm SignatureAlgorithm) hash() crypto.Hash {
+	switch algorithm {
+	case SignatureECDSAP256:
+		return crypto.SHA256
+	case SignatureECDSAP384:
+		return crypto.SHA384
+	case SignatureECDSAP521:
+		return crypto.SHA512
+	default:
+		return crypto.Hash(0)
+	}
+}
+
+// Envelope is a signed container for the POLO encoded form of some payload.
+// An Envelope is created with Seal and its payload can only be decoded with Open,
+// which refuses to decode the payload unless its signature is verified.
+type Envelope struct {
+	// Payload is the canonical POLO wire of the sealed payload
+	Payload Raw
+	// Algorithm is the algorithm of the signature
+	Algorithm SignatureAlgorithm
+	// Signer is the PKIX (DER) encoded public key of the signer
+	Signer []byte
+	// Signature is the signature over the payload
+	Signature []byte
+}
+
+// Seal serializes a payload into its POLO byte form and signs it with the given crypto.Signer.
+// The signer must have an Ed25519 or ECDSA (P-256, P-384 or P-521) public key.
+// Accepts EncodingOptions to modify the encoding behaviour of the payload.
+func Seal(payload any, signer crypto.Signer, options ...EncodingOptions) (Envelope, error) {
+	algorithm, err := signatureAlgorithm(signer.Public())
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	// Encode the public key of the signer
+	key, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return Envelope{}, fmt.Errorf("could not seal envelope: %w", err)
+	}
+
+	// Polorize the payload into its canonical form
+	wire, err := Polorize(payload, append(slices.Clip(options), uncompressed())...)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	// Sign the digest of the payload (or the payload itself for Ed25519)
+	signature, err := signer.Sign(rand.Reader, signatureDigest(algorithm, wire), algorithm.hash())
+	if err != nil {
+		return Envelope{}, fmt.Errorf("could not seal envelope: %w", err)
+	}
+
+	return Envelope{Payload: wire, Algorithm: algorithm, Signer: key, Signature: signature}, nil
+}
+
+// Open verifies the signature of an Envelope and decodes its payload into the given object which must be a pointer.
+// The Envelope must be signed by the given verifier, which must be an Ed25519 or ECDSA public key. The payload
+// is not decoded if the verification fails. Accepts EncodingOptions to modify the decoding behaviour of the payload.
+func Open(envelope Envelope, verifier crypto.PublicKey, object any, options ...EncodingOptions) error {
+	if err := envelope.Verify(verifier); err != nil {
+		return err
+	}
+
+	return Depolorize(object, envelope.Payload, options...)
+}
+
+// Verify checks that the Envelope is signed by the given public key, which must be an Ed25519 or ECDSA key.
+// Returns ErrUntrustedSigner if the Envelope has a different signer and ErrInvalidSignature if the signature
+// does not verify for the payload.
+func (envelope Envelope) Verify(verifier crypto.PublicKey) error {
+	// Decode the public key of the signer
+	key, err := x509.ParsePKIXPublicKey(envelope.Signer)
+	if err != nil {
+		return fmt.Errorf("could not open envelope: %w", err)
+	}
+
+	// The algorithm of the envelope must match the key of the signer
+	algorithm, err := signatureAlgorithm(key)
+	if err != nil {
+		return err
+	}
+
+	if algorithm != envelope.Algorithm {
+		return fmt.Errorf("could not open envelope: algorithm %v does not match signer key", envelope.Algorithm)
+	}
+
+	// The signer of the envelope must be the verifier
+	if trusted, ok := key.(interface{ Equal(crypto.PublicKey) bool }); !ok || !trusted.Equal(verifier) {
+		return ErrUntrustedSigner
+	}
+
+	digest := signatureDigest(algorithm, envelope.Payload)
+
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, digest, envelope.Signature) {
+			return ErrInvalidSignature
+		}
+
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, envelope.Signature) {
+			return ErrInvalidSignature
+		}
+	}
+
+	return nil
+}
+
+// signatureAlgorithm returns the SignatureAlgorithm for a public key.
+// Returns an error if the key is not an Ed25519 or ECDSA key with a supported curve.
+func signatureAlgorithm(key crypto.PublicKey) (SignatureAlgorithm, error) {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return SignatureEd25519, nil
+
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return SignatureECDSAP256, nil
+		case elliptic.P384():
+			return SignatureECDSAP384, nil
+		case elliptic.P521():
+			return SignatureECDSAP521, nil
+		default:
+			return 0, fmt.Errorf("unsupported signer key: ecdsa curve %v", key.Curve.Params().Name)
+		}
+
+	default:
+		return 0, fmt.Errorf("unsupported signer key: %T", key)
+	}
+}
+
+// signatureDigest returns the message that is signed for a payload with the given algorithm.
+// This is the digest of the payload for ECDSA and the payload itself for Ed25519.
+func signatureDigest(algorithm SignatureAlgorithm, payload []byte) []byte {
+	hash := algorithm.hash()
+	if hash == crypto.Hash(0) {
+		return payload
+	}
+
+	h := hash.New()
+	_, _ = h.Write(payload)
+
+	return h.Sum(nil)
+}
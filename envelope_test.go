@@ -0,0 +1,107 @@
+package polo
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope(t *testing.T) {
+	type Transfer struct {
+		From, To string
+		Amount   uint64
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signers := map[SignatureAlgorithm]crypto.Signer{SignatureEd25519: edKey}
+
+	for algorithm, curve := range map[SignatureAlgorithm]elliptic.Curve{
+		SignatureECDSAP256: elliptic.P256(),
+		SignatureECDSAP384: elliptic.P384(),
+		SignatureECDSAP521: elliptic.P521(),
+	} {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+
+		signers[algorithm] = key
+	}
+
+	transfer := Transfer{"alice", "bob", 100}
+
+	for algorithm, signer := range signers {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			envelope, err := Seal(transfer, signer)
+			require.NoError(t, err)
+			assert.Equal(t, algorithm, envelope.Algorithm)
+
+			// The payload must be the canonical wire of the object
+			wire, err := Polorize(transfer)
+			require.NoError(t, err)
+			assert.Equal(t, Raw(wire), envelope.Payload)
+
+			// Envelopes must survive being encoded
+			encoded, err := Polorize(envelope)
+			require.NoError(t, err)
+
+			decoded := new(Envelope)
+			require.NoError(t, Depolorize(decoded, encoded))
+
+			opened := new(Transfer)
+			require.NoError(t, Open(*decoded, signer.Public(), opened))
+			assert.Equal(t, transfer, *opened)
+
+			// Tampered payload
+			tampered := *decoded
+			tampered.Payload, _ = Polorize(Transfer{"alice", "bob", 1000})
+
+			opened = new(Transfer)
+			assert.ErrorIs(t, Open(tampered, signer.Public(), opened), ErrInvalidSignature)
+			assert.Equal(t, Transfer{}, *opened)
+
+			// Untrusted signer
+			other, _, err := ed25519.GenerateKey(rand.Reader)
+			require.NoError(t, err)
+			assert.ErrorIs(t, Open(*decoded, other, opened), ErrUntrustedSigner)
+			assert.ErrorIs(t, Open(*decoded, nil, opened), ErrUntrustedSigner)
+		})
+	}
+
+	t.Run("Algorithm Mismatch", func(t *testing.T) {
+		envelope, err := Seal(transfer, signers[SignatureECDSAP256])
+		require.NoError(t, err)
+
+		envelope.Algorithm = SignatureECDSAP384
+		assert.EqualError(t, envelope.Verify(signers[SignatureECDSAP256].Public()),
+			"could not open envelope: algorithm ecdsa-p384 does not match signer key")
+	})
+
+	t.Run("Unsupported Signer", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 1024) //nolint:gosec
+		require.NoError(t, err)
+
+		_, err = Seal(transfer, key)
+		assert.EqualError(t, err, "unsupported signer key: *rsa.PublicKey")
+
+		key224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+		require.NoError(t, err)
+
+		_, err = Seal(transfer, key224)
+		assert.EqualError(t, err, "unsupported signer key: ecdsa curve P-224")
+	})
+
+	t.Run("Unsupported Payload", func(t *testing.T) {
+		_, err := Seal(make(chan int), edKey)
+		assert.EqualError(t, err, "incompatible value error: unsupported type: chan int [chan]")
+	})
+
+	assert.Equal(t, "unknown(9)", SignatureAlgorithm(9).String())
+}
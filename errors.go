@@ -21,6 +21,15 @@ var (
 	ErrObjectNotSettable = errors.New("object is not settable")
 	// ErrInsufficientWire is an error for when the data in depolorizer is exhausted
 	ErrInsufficientWire = errors.New("insufficient data in wire for decode")
+
+	// ErrInvalidSignature is an error for when the signature of an Envelope does not verify for its payload
+	ErrInvalidSignature = errors.New("invalid envelope signature")
+	// ErrUntrustedSigner is an error for when an Envelope is not signed by the expected signer
+	ErrUntrustedSigner = errors.New("untrusted envelope signer")
+	// ErrDecompressLimit is an error for when the size of a decompressed wire exceeds the limit
+	ErrDecompressLimit = errors.New("decompressed wire exceeds limit")
+	// ErrCompressedWire is an error for when a compressed wire is decoded without the Decompression option
+	ErrCompressedWire = errors.New("compressed wire cannot be decoded without decompression")
 )
 
 // MalformedTagError is an error for when a consumed varint for a tag is malformed
@@ -33,6 +42,29 @@ func (err MalformedTagError) Error() string {
 	return fmt.Sprintf("malformed tag: %v", err.msg)
 }
 
+// MalformedLoadError is an error for when the load of a compound wire is malformed
+type MalformedLoadError struct {
+	msg string
+	err error
+}
+
+// Error implements the error interface for MalformedLoadError
+func (err MalformedLoadError) Error() string {
+	switch {
+	case err.err == nil:
+		return fmt.Sprintf("load convert fail: %v", err.msg)
+	case err.msg == "":
+		return fmt.Sprintf("load convert fail: %v", err.err)
+	default:
+		return fmt.Sprintf("load convert fail: %v: %v", err.msg, err.err)
+	}
+}
+
+// Unwrap returns the error that caused the MalformedLoadError, if any
+func (err MalformedLoadError) Unwrap() error {
+	return err.err
+}
+
 // IncompatibleWireError is an error for when an object cannot be decoded from some wire data
 type IncompatibleWireError struct {
 	msg string
@@ -69,3 +101,38 @@ func (err IncompatibleValueError) Error() string {
 func UnsupportedTypeError(t reflect.Type) IncompatibleValueError {
 	return IncompatibleValueError{fmt.Sprintf("unsupported type: %v [%v]", t, t.Kind())}
 }
+
+// SchemaError is an error for when a Document does not conform to a DocSchema.
+// It reports all the keys of the Document that violate the schema together.
+type SchemaError struct {
+	// Missing is the sorted list of required keys that are absent from the Document
+	Missing []string
+	// Unexpected is the sorted list of keys in the Document that are not declared in the schema
+	Unexpected []string
+	// Mistyped is the set of keys in the Document whose values have an unexpected wire type
+	Mistyped map[string]IncompatibleWireError
+}
+
+// Error implements the error interface for SchemaError
+func (err SchemaError) Error() string {
+	violations := make([]string, 0, 3)
+
+	if len(err.Missing) != 0 {
+		violations = append(violations, fmt.Sprintf("missing keys [%v]", strings.Join(err.Missing, ", ")))
+	}
+
+	if len(err.Unexpected) != 0 {
+		violations = append(violations, fmt.Sprintf("unexpected keys [%v]", strings.Join(err.Unexpected, ", ")))
+	}
+
+	if len(err.Mistyped) != 0 {
+		mistyped := make([]string, 0, len(err.Mistyped))
+		for _, key := range sortedKeys(err.Mistyped) {
+			mistyped = append(mistyped, fmt.Sprintf("%v: %v", key, err.Mistyped[key].msg))
+		}
+
+		violations = append(violations, fmt.Sprintf("mistyped keys [%v]", strings.Join(mistyped, "; ")))
+	}
+
+	return fmt.Sprintf("schema violation: %v", strings.Join(violations, ", "))
+}
@@ -0,0 +1,165 @@
+package polo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fieldTag is the parsed form of the 'polo' struct tag of a struct field.
+// The tag has the form `polo:"name,option,..."` where name is the document key for the
+// field (defaults to the field name if empty) and each option modifies how the field is
+// handled. A tag of '-' skips the field entirely.
+//
+// Supported options:
+//   - unknown: the field collects document keys that do not match any other field (must be a Document)
+//   - alias=a|b: the field also decodes from the document keys 'a' or 'b' (encoding always uses name)
+//   - default=v: the field is set to v when decoding if it is absent from the wire or null
+//   - rest: the field collects trailing pack elements that do not match any other field (must be a []Any)
+type fieldTag struct {
+	// name is the document key for the field
+	name string
+	// skip is set for fields that are not encoded or decoded
+	skip bool
+	// unknown is set for the field that collects unmatched document keys
+	unknown bool
+	// rest is set for the field that collects trailing pack elements
+	rest bool
+	// aliases are the alternate document keys for the field
+	aliases []string
+	// def is the default value of the field, if hasDefault is set
+	def        string
+	hasDefault bool
+}
+
+// parseFieldTag parses the 'polo' struct tag of a struct field.
+// Unexported fields and fields tagged with '-' are marked to be skipped.
+func parseFieldTag(field reflect.StructField) fieldTag {
+	tag := field.Tag.Get("polo")
+	if !field.IsExported() || tag == "-" {
+		return fieldTag{skip: true}
+	}
+
+	// Determine doc key for struct field. Field name is used
+	// directly if there is no provided in the polo tag.
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	parsed := fieldTag{name: name}
+
+	// Parse each option in the tag
+	for options != "" {
+		var option string
+		option, options, _ = strings.Cut(options, ",")
+
+		switch {
+		case option == "unknown":
+			parsed.unknown = true
+		case option == "rest":
+			parsed.rest = true
+		case strings.HasPrefix(option, "alias="):
+			parsed.aliases = append(parsed.aliases, strings.Split(strings.TrimPrefix(option, "alias="), "|")...)
+		case strings.HasPrefix(option, "default="):
+			parsed.def, parsed.hasDefault = strings.TrimPrefix(option, "default="), true
+		}
+	}
+
+	return parsed
+}
+
+// fieldTagCache is a cache of the parsed field tags of struct types, keyed by their reflect.Type
+var fieldTagCache sync.Map
+
+// fieldTags returns the parsed 'polo' struct tags of each field of a struct type, in field order.
+// The tags of each struct type are only parsed once and must not be modified.
+func fieldTags(t reflect.Type) []fieldTag {
+	if tags, ok := fieldTagCache.Load(t); ok {
+		return tags.([]fieldTag) //nolint:forcetypeassert
+	}
+
+	tags := make([]fieldTag, t.NumField())
+	for i := range tags {
+		tags[i] = parseFieldTag(t.Field(i))
+	}
+
+	cached, _ := fieldTagCache.LoadOrStore(t, tags)
+
+	return cached.([]fieldTag) //nolint:forcetypeassert
+}
+
+// lookup returns the key in the document that matches the field tag.
+// The name of the field is preferred, followed by its aliases in order. If keys is
+// not nil, it must be the sorted keys of the document and is searched for a key that
+// matches the name or any alias case-insensitively, if there is no exact match.
+func (tag fieldTag) lookup(doc Document, keys []string) (string, bool) {
+	// Check for an exact match of the name
+	if _, ok := doc[tag.name]; ok {
+		return tag.name, true
+	}
+
+	// Check for an exact match of any alias
+	for _, alias := range tag.aliases {
+		if _, ok := doc[alias]; ok {
+			return alias, true
+		}
+	}
+
+	// Check for a case-insensitive match of the name or any alias
+	for _, key := range keys {
+		if strings.EqualFold(key, tag.name) {
+			return key, true
+		}
+
+		for _, alias := range tag.aliases {
+			if strings.EqualFold(key, alias) {
+				return key, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// unknownField returns the index of the struct field tagged to collect unknown document keys.
+// Returns -1 if there is no such field and an error if the field is not a Document or if
+// more than one field is tagged as such.
+func unknownField(t reflect.Type) (int, error) {
+	return optionField(t, "unknown", reflect.TypeOf(Document{}), func(tag fieldTag) bool { return tag.unknown })
+}
+
+// restField returns the index of the struct field tagged to collect trailing pack elements.
+// Returns -1 if there is no such field and an error if the field is not a []Any or if
+// more than one field is tagged as such.
+func restField(t reflect.Type) (int, error) {
+	return optionField(t, "rest", reflect.TypeOf([]Any{}), func(tag fieldTag) bool { return tag.rest })
+}
+
+// optionField returns the index of the struct field whose tag is selected by the given function.
+// The field must have the expected type and at most one field of the struct may be selected.
+func optionField(t reflect.Type, option string, expected reflect.Type, selected func(fieldTag) bool) (int, error) {
+	index := -1
+
+	for i, tag := range fieldTags(t) {
+		if tag.skip || !selected(tag) {
+			continue
+		}
+
+		field := t.Field(i)
+		if field.Type != expected {
+			return -1, IncompatibleValueError{
+				fmt.Sprintf("%v field [%v.%v <%v>] must be %v", option, t, field.Name, field.Type, expected),
+			}
+		}
+
+		if index != -1 {
+			return -1, IncompatibleValueError{fmt.Sprintf("struct [%v] has more than one %v field", t, option)}
+		}
+
+		index = i
+	}
+
+	return index, nil
+}
@@ -0,0 +1,125 @@
+package polo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFieldTag(t *testing.T) {
+	type Tagged struct {
+		Plain   string
+		Named   string   `polo:"named"`
+		Skipped string   `polo:"-"`
+		Options string   `polo:",unknown"`
+		Both    Document `polo:"both,unknown"`
+		hidden  string   //nolint:unused
+		Aliased string   `polo:"name,alias=Name|full_name"`
+		Skip    string   `polo:"-,alias=skip"`
+		Rest    []Any    `polo:",rest"`
+	}
+
+	typ := reflect.TypeOf(Tagged{})
+
+	tests := []fieldTag{
+		{name: "Plain"},
+		{name: "named"},
+		{skip: true},
+		{name: "Options", unknown: true},
+		{name: "both", unknown: true},
+		{skip: true},
+		{name: "name", aliases: []string{"Name", "full_name"}},
+		{name: "-", aliases: []string{"skip"}},
+		{name: "Rest", rest: true},
+	}
+
+	for i, expected := range tests {
+		assert.Equal(t, expected, parseFieldTag(typ.Field(i)), typ.Field(i).Name)
+	}
+}
+
+func TestFieldTag_Lookup(t *testing.T) {
+	tag := fieldTag{name: "name", aliases: []string{"Name", "full_name"}}
+
+	tests := []struct {
+		doc         Document
+		insensitive bool
+		key         string
+		matched     bool
+	}{
+		{Document{"name": nil, "Name": nil}, false, "name", true},
+		{Document{"full_name": nil, "Name": nil}, false, "Name", true},
+		{Document{"full_name": nil}, false, "full_name", true},
+		{Document{"FullName": nil}, false, "", false},
+		{Document{"NAME": nil}, false, "", false},
+		{Document{"NAME": nil}, true, "NAME", true},
+		{Document{"Full_Name": nil, "fullname": nil}, true, "Full_Name", true},
+		{Document{"fullname": nil}, true, "", false},
+	}
+
+	for _, test := range tests {
+		var keys []string
+		if test.insensitive {
+			keys = test.doc.Keys()
+		}
+
+		key, matched := tag.lookup(test.doc, keys)
+		assert.Equal(t, test.key, key)
+		assert.Equal(t, test.matched, matched)
+	}
+}
+
+func TestUnknownField(t *testing.T) {
+	type None struct {
+		A string
+	}
+
+	type One struct {
+		A     string
+		Extra Document `polo:",unknown"`
+	}
+
+	type Mistyped struct {
+		Extra map[string]string `polo:",unknown"`
+	}
+
+	type Many struct {
+		Extra1 Document `polo:",unknown"`
+		Extra2 Document `polo:",unknown"`
+	}
+
+	index, err := unknownField(reflect.TypeOf(None{}))
+	require.NoError(t, err)
+	assert.Equal(t, -1, index)
+
+	index, err = unknownField(reflect.TypeOf(One{}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	_, err = unknownField(reflect.TypeOf(Mistyped{}))
+	assert.EqualError(t, err,
+		"incompatible value error: unknown field [polo.Mistyped.Extra <map[string]string>] must be polo.Document")
+
+	_, err = unknownField(reflect.TypeOf(Many{}))
+	assert.EqualError(t, err, "incompatible value error: struct [polo.Many] has more than one unknown field")
+}
+
+func TestRestField(t *testing.T) {
+	type One struct {
+		Rest []Any `polo:",rest"`
+		A    string
+	}
+
+	type Mistyped struct {
+		Rest []Raw `polo:",rest"`
+	}
+
+	index, err := restField(reflect.TypeOf(One{}))
+	require.NoError(t, err)
+	assert.Equal(t, 0, index)
+
+	_, err = restField(reflect.TypeOf(Mistyped{}))
+	assert.EqualError(t, err, "incompatible value error: rest field [polo.Mistyped.Rest <[]polo.Raw>] must be []polo.Any")
+}
@@ -1,6 +1,6 @@
 module github.com/sarvalabs/go-polo
 
-go 1.22
+go 1.23
 
 retract v0.4.4 // published incorrectly
 
@@ -0,0 +1,85 @@
+package polo
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+)
+
+// Hash serializes an object into its POLO byte form and writes it into the given hash.Hash.
+// Returns the digest of the hash after the write, which does not reset the state of the hash.
+// The full wire is encoded into reusable (pooled) encoding buffers, whose head and body are
+// written into the hash without being concatenated into a single slice of bytes. The digest
+// is equal to hashing the output of Polorize for the object.
+// The Compression option is ignored, so that the digest is always of the uncompressed wire
+// and does not depend on the compressor.
+func Hash(object any, h hash.Hash, options ...EncodingOptions) ([]byte, error) {
+	// Obtain a polorizer from the pool and return it once done
+	polorizer := acquirePolorizer(options...)
+	defer polorizerPool.Put(polorizer)
+
+	// Hashes are always of the uncompressed wire
+	polorizer.cfg.compressor = nil
+
+	// Polorize the object
+	if err := polorizer.Polorize(object); err != nil {
+		return nil, err
+	}
+
+	// Write the head followed by the body of the writebuffer.
+	// Writes to a hash.Hash never return an error.
+	_, _ = h.Write(polorizer.wb.head)
+	_, _ = h.Write(polorizer.wb.body)
+
+	return h.Sum(nil), nil
+}
+
+// SHA256 returns the SHA-256 digest of the POLO byte form of an object.
+func SHA256(object any, options ...EncodingOptions) ([sha256.Size]byte, error) {
+	var digest [sha256.Size]byte
+
+	sum, err := Hash(object, sha256.New(), options...)
+	if err != nil {
+		return digest, err
+	}
+
+	copy(digest[:], sum)
+
+	return digest, nil
+}
+
+// SHA512 returns the SHA-512 digest of the POLO byte form of an object.
+func SHA512(object any, options ...EncodingOptions) ([sha512.Size]byte, error) {
+	var digest [sha512.Size]byte
+
+	sum, err := Hash(object, sha512.New(), options...)
+	if err != nil {
+		return digest, err
+	}
+
+	copy(digest[:], sum)
+
+	return digest, nil
+}
+
+// SHA512_256 returns the SHA-512/256 digest of the POLO byte form of an object.
+func SHA512_256(object any, options ...EncodingOptions) ([sha512.Size256]byte, error) { //nolint:revive,stylecheck
+	var digest [sha512.Size256]byte
+
+	sum, err := Hash(object, sha512.New512_256(), options...)
+	if err != nil {
+		return digest, err
+	}
+
+	copy(digest[:], sum)
+
+	return digest, nil
+}
+
+// Hash writes the POLO wire of the Any into the given hash.Hash and returns its digest.
+// The digest is equal to that of Hash for the object that the Any was encoded from.
+func (wire Any) Hash(h hash.Hash) []byte {
+	_, _ = h.Write(wire)
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,94 @@
+package polo
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash(t *testing.T) {
+	f := fuzz.New().NilChance(0.1)
+
+	for i := 0; i < 100; i++ {
+		var object MapObject
+
+		f.Fuzz(&object)
+
+		wire, err := Polorize(object)
+		require.NoError(t, err)
+
+		// Hash must match hashing the wire from Polorize
+		expected := sha256.Sum256(wire)
+
+		digest, err := Hash(object, sha256.New())
+		require.NoError(t, err)
+		assert.Equal(t, expected[:], digest)
+
+		sha, err := SHA256(object)
+		require.NoError(t, err)
+		assert.Equal(t, expected, sha)
+
+		assert.Equal(t, expected[:], Any(wire).Hash(sha256.New()))
+	}
+
+	t.Run("SHA512", func(t *testing.T) {
+		wire, err := Polorize("orange")
+		require.NoError(t, err)
+
+		digest, err := SHA512("orange")
+		require.NoError(t, err)
+		assert.Equal(t, sha512.Sum512(wire), digest)
+
+		digest256, err := SHA512_256("orange")
+		require.NoError(t, err)
+		assert.Equal(t, sha512.Sum512_256(wire), digest256)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		type Fruit struct {
+			Name string
+		}
+
+		wire, err := Polorize(Fruit{"orange"}, DocStructs())
+		require.NoError(t, err)
+
+		digest, err := SHA256(Fruit{"orange"}, DocStructs())
+		require.NoError(t, err)
+		assert.Equal(t, sha256.Sum256(wire), digest)
+	})
+
+	t.Run("Compression", func(t *testing.T) {
+		wire, err := Polorize("orange")
+		require.NoError(t, err)
+
+		// Hashes are of the uncompressed wire regardless of compression
+		digest, err := SHA256("orange", Compression(Gzip(gzip.BestCompression)))
+		require.NoError(t, err)
+		assert.Equal(t, sha256.Sum256(wire), digest)
+	})
+
+	t.Run("Streaming", func(t *testing.T) {
+		var h hash.Hash = sha256.New()
+
+		_, err := Hash("orange", h)
+		require.NoError(t, err)
+		_, err = Hash("apple", h)
+		require.NoError(t, err)
+
+		first, _ := Polorize("orange")
+		second, _ := Polorize("apple")
+
+		assert.Equal(t, sha256.Sum256(append(first, second...)), [sha256.Size]byte(h.Sum(nil)))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := SHA256(make(chan int))
+		assert.EqualError(t, err, "incompatible value error: unsupported type: chan int [chan]")
+	})
+}
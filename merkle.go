@@ -0,0 +1,378 @@
+package polo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"math/bits"
+)
+
+// Domain separation prefixes for the nodes of a merkle tree
+const (
+	merkleLeaf  byte = 0x00 // atomic elements
+	merkleInner byte = 0x01 // inner nodes of the binary tree over the children of a compound element
+	merkleEntry byte = 0x02 // document key-value pairs
+	merkleNode  byte = 0x03 // compound elements (packs and documents)
+)
+
+// MerkleRoot computes the merkle root of some POLO wire with hashes created by the given function.
+//
+// Atomic elements are hashed as leaves. Packs and documents are hashed as a binary merkle tree over
+// the hashes of their children, which are their elements for packs and their key-value pairs (ordered
+// by key) for documents. The root of the tree is hashed with the wire type and the number of children,
+// which binds the positions of the children. Children that are packs or documents are hashed recursively.
+// This allows a single field of a large object to be proven against the root with MerkleProof.
+func MerkleRoot(wire []byte, h func() hash.Hash) ([]byte, error) {
+	return merkleHasher{h}.element(wire)
+}
+
+// Proof is an inclusion proof for an element of some POLO wire against its merkle root.
+// It is generated with MerkleProof and checked with VerifyProof.
+type Proof struct {
+	// Value is the wire of the proven element
+	Value Any
+	// Steps are the compound elements along the path of the proven
+	// element, ordered from its immediate parent up to the root
+	Steps []ProofStep
+}
+
+// ProofStep is a single compound element along the path of a Proof
+type ProofStep struct {
+	// Wire is the wire type of the compound element, either WirePack or WireDoc
+	Wire WireType
+	// Key is the document key of the child along the path, empty for packs
+	Key string
+	// Index is the position of the child along the path among all the children of the compound element
+	Index int
+	// Count is the number of children of the compound element
+	Count int
+	// Siblings are the hashes required to compute the root of the compound
+	// element from the hash of the child, ordered from the bottom up
+	Siblings [][]byte
+}
+
+// MerkleProof generates an inclusion proof for the element at the given path in some POLO wire, with
+// hashes created by the given function. The path is a sequence of document keys separated by dots, each
+// of which can be followed by pack indices in brackets, e.g, "inputs[2].amount". If the wire is a pack,
+// the path must start with an index, e.g, "[1].amount".
+// Returns an error if the path is malformed or there is no element at the path.
+func MerkleProof(wire []byte, path string, h func() hash.Hash) (Proof, error) {
+	steps, err := parseSteps(path, false)
+	if err != nil {
+		return Proof{}, err
+	}
+
+	hasher := merkleHasher{h}
+	proof := Proof{Steps: make([]ProofStep, len(steps))}
+
+	for i, step := range steps {
+		// parent represents the path to the element being descended into
+		parent := ""
+		if i > 0 {
+			parent = path[:steps[i-1].end]
+		}
+
+		wiretype, children, err := merkleChildren(wire)
+		if err != nil {
+			return Proof{}, fmt.Errorf("merkle value at path '%v' could not be decoded: %w", parent, err)
+		}
+
+		// Find the position of the child for the step
+		index := -1
+
+		switch {
+		case step.index >= 0:
+			if wiretype != WirePack {
+				return Proof{}, fmt.Errorf("merkle value at path '%v' could not be indexed: %w",
+					parent, IncompatibleWireType(wiretype, WirePack))
+			}
+
+			if step.index < len(children) {
+				index = step.index
+			}
+
+		default:
+			if wiretype != WireDoc {
+				return Proof{}, fmt.Errorf("merkle value at path '%v' is not a document: %w",
+					parent, IncompatibleWireType(wiretype, WireDoc))
+			}
+
+			for position, child := range children {
+				if child.key == step.key {
+					index = position
+					break
+				}
+			}
+		}
+
+		if index == -1 {
+			return Proof{}, fmt.Errorf("merkle value not found for path '%v'", path[:step.end])
+		}
+
+		// Hash all the children to obtain the siblings along the audit path
+		hashes, err := hasher.children(wiretype, children)
+		if err != nil {
+			return Proof{}, err
+		}
+
+		// Steps of the proof are ordered from the bottom up
+		proof.Steps[len(steps)-1-i] = ProofStep{
+			Wire:     wiretype,
+			Key:      children[index].key,
+			Index:    index,
+			Count:    len(children),
+			Siblings: hasher.path(index, hashes),
+		}
+
+		wire = children[index].wire
+	}
+
+	proof.Value = bytes.Clone(wire)
+
+	return proof, nil
+}
+
+// VerifyProof checks that an inclusion proof is valid for the element at the given path against a merkle root,
+// with hashes created by the given function. The value of the element is available as the Value of the Proof.
+// Returns an error if the proof does not match the path or if it does not resolve to the merkle root.
+func VerifyProof(root []byte, path string, proof Proof, h func() hash.Hash) error {
+	steps, err := parseSteps(path, false)
+	if err != nil {
+		return err
+	}
+
+	if len(steps) != len(proof.Steps) {
+		return fmt.Errorf("invalid merkle proof for path '%v': expected %v steps, got %v",
+			path, len(steps), len(proof.Steps))
+	}
+
+	hasher := merkleHasher{h}
+
+	// Hash the proven element
+	node, err := hasher.element(proof.Value)
+	if err != nil {
+		return fmt.Errorf("invalid merkle proof for path '%v': %w", path, err)
+	}
+
+	for i, step := range proof.Steps {
+		// Check that the step of the proof matches the step of the path
+		expected := steps[len(steps)-1-i]
+
+		if (expected.index >= 0 && (step.Wire != WirePack || step.Index != expected.index)) ||
+			(expected.index < 0 && (step.Wire != WireDoc || step.Key != expected.key)) {
+			return fmt.Errorf("invalid merkle proof for path '%v': step mismatch at path '%v'", path, path[:expected.end])
+		}
+
+		// Document children are hashed along with their key
+		if step.Wire == WireDoc {
+			node = hasher.entry(step.Key, node)
+		}
+
+		// Compute the root of the compound element from the child
+		if node, err = hasher.root(step.Index, step.Count, node, step.Siblings); err != nil {
+			return fmt.Errorf("invalid merkle proof for path '%v': %w", path, err)
+		}
+
+		node = hasher.node(step.Wire, step.Count, node)
+	}
+
+	if !bytes.Equal(node, root) {
+		return fmt.Errorf("invalid merkle proof for path '%v': root mismatch", path)
+	}
+
+	return nil
+}
+
+// merkleChild is a child of a compound element in a merkle tree
+type merkleChild struct {
+	key  string // document key of the child, empty for pack elements
+	wire []byte
+}
+
+// merkleChildren returns the wire type of some POLO wire and its children if it is a pack or a document.
+// Document children are ordered by their key. Empty wires are treated as atomic and have no children.
+func merkleChildren(wire []byte) (WireType, []merkleChild, error) {
+	if len(wire) == 0 {
+		return WireNull, nil, nil
+	}
+
+	depolorizer, err := NewDepolorizer(wire)
+	if err != nil {
+		return WireNull, nil, err
+	}
+
+	switch wiretype, _ := depolorizer.Peek(); wiretype {
+	case WirePack:
+		elements, err := pathElements(wire)
+		if err != nil {
+			return wiretype, nil, err
+		}
+
+		children := make([]merkleChild, 0, len(elements))
+		for _, element := range elements {
+			children = append(children, merkleChild{wire: element})
+		}
+
+		return wiretype, children, nil
+
+	case WireDoc:
+		document, err := depolorizer.DepolorizeDocument()
+		if err != nil {
+			return wiretype, nil, err
+		}
+
+		children := make([]merkleChild, 0, len(document))
+		for _, key := range document.Keys() {
+			children = append(children, merkleChild{key: key, wire: document[key]})
+		}
+
+		return wiretype, children, nil
+
+	default:
+		return wiretype, nil, nil
+	}
+}
+
+// merkleHasher computes the hashes of the nodes of a merkle tree
+type merkleHasher struct {
+	new func() hash.Hash
+}
+
+// sum returns the hash of the given parts prefixed with a domain separation byte
+func (mh merkleHasher) sum(prefix byte, parts ...[]byte) []byte {
+	h := mh.new()
+	_, _ = h.Write([]byte{prefix})
+
+	for _, part := range parts {
+		_, _ = h.Write(part)
+	}
+
+	return h.Sum(nil)
+}
+
+// element returns the hash of some POLO wire, recursively hashing its children if it is a pack or a document
+func (mh merkleHasher) element(wire []byte) ([]byte, error) {
+	wiretype, children, err := merkleChildren(wire)
+	if err != nil {
+		return nil, err
+	}
+
+	// Atomic elements are hashed as leaves
+	if wiretype != WirePack && wiretype != WireDoc {
+		return mh.sum(merkleLeaf, wire), nil
+	}
+
+	hashes, err := mh.children(wiretype, children)
+	if err != nil {
+		return nil, err
+	}
+
+	return mh.node(wiretype, len(hashes), mh.tree(hashes)), nil
+}
+
+// node returns the hash of a compound element from its wire type, the number of its children and the
+// root of the binary merkle tree over them. Committing the count fixes the shape of the tree, so that
+// the sibling hashes of a child cannot be reused to prove it at a different position.
+func (mh merkleHasher) node(wiretype WireType, count int, tree []byte) []byte {
+	return mh.sum(merkleNode, []byte{byte(wiretype)}, appendVarint(nil, uint64(count)), tree)
+}
+
+// children returns the hashes of the children of a compound element with the given wire type.
+// Document children are hashed along with their key.
+func (mh merkleHasher) children(wiretype WireType, children []merkleChild) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(children))
+
+	for _, child := range children {
+		node, err := mh.element(child.wire)
+		if err != nil {
+			return nil, err
+		}
+
+		if wiretype == WireDoc {
+			node = mh.entry(child.key, node)
+		}
+
+		hashes = append(hashes, node)
+	}
+
+	return hashes, nil
+}
+
+// entry returns the hash of a document key-value pair from the key and the hash of the value
+func (mh merkleHasher) entry(key string, value []byte) []byte {
+	return mh.sum(merkleEntry, appendVarint(nil, uint64(len(key))), []byte(key), value)
+}
+
+// tree returns the root of the binary merkle tree over the given hashes.
+// The hashes are split such that the left subtree is the largest perfect tree possible.
+func (mh merkleHasher) tree(hashes [][]byte) []byte {
+	switch len(hashes) {
+	case 0:
+		return mh.sum(merkleInner)
+	case 1:
+		return hashes[0]
+	default:
+		split := merkleSplit(len(hashes))
+		return mh.sum(merkleInner, mh.tree(hashes[:split]), mh.tree(hashes[split:]))
+	}
+}
+
+// path returns the sibling hashes required to compute the root of the binary
+// merkle tree over the given hashes from the hash at index, ordered from the bottom up
+func (mh merkleHasher) path(index int, hashes [][]byte) [][]byte {
+	if len(hashes) <= 1 {
+		return nil
+	}
+
+	split := merkleSplit(len(hashes))
+	if index < split {
+		return append(mh.path(index, hashes[:split]), mh.tree(hashes[split:]))
+	}
+
+	return append(mh.path(index-split, hashes[split:]), mh.tree(hashes[:split]))
+}
+
+// root returns the root of a binary merkle tree with count hashes from the hash
+// at index and its sibling hashes along the path, ordered from the bottom up
+func (mh merkleHasher) root(index, count int, node []byte, siblings [][]byte) ([]byte, error) {
+	if index < 0 || index >= count {
+		return nil, errors.New("index out of range")
+	}
+
+	if count == 1 {
+		if len(siblings) != 0 {
+			return nil, errors.New("unexpected siblings")
+		}
+
+		return node, nil
+	}
+
+	if len(siblings) == 0 {
+		return nil, errors.New("insufficient siblings")
+	}
+
+	// The last sibling is at the top of the path
+	split, top := merkleSplit(count), siblings[len(siblings)-1]
+
+	if index < split {
+		left, err := mh.root(index, split, node, siblings[:len(siblings)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		return mh.sum(merkleInner, left, top), nil
+	}
+
+	right, err := mh.root(index-split, count-split, node, siblings[:len(siblings)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	return mh.sum(merkleInner, top, right), nil
+}
+
+// merkleSplit returns the largest power of two that is smaller than count, which must be greater than 1
+func merkleSplit(count int) int {
+	return 1 << (bits.Len(uint(count-1)) - 1)
+}
@@ -0,0 +1,231 @@
+package polo
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkleSplit(t *testing.T) {
+	tests := map[int]int{2: 1, 3: 2, 4: 2, 5: 4, 8: 4, 9: 8, 1000: 512}
+
+	for count, split := range tests {
+		assert.Equal(t, split, merkleSplit(count), count)
+	}
+}
+
+func TestMerkleRoot(t *testing.T) {
+	t.Run("Atomic", func(t *testing.T) {
+		wire, err := Polorize("orange")
+		require.NoError(t, err)
+
+		root, err := MerkleRoot(wire, sha256.New)
+		require.NoError(t, err)
+
+		expected := sha256.Sum256(append([]byte{merkleLeaf}, wire...))
+		assert.Equal(t, expected[:], root)
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		f := fuzz.New().NilChance(0.1)
+
+		for i := 0; i < 100; i++ {
+			var object PathObject
+
+			f.Fuzz(&object)
+
+			wire, err := Polorize(object, DocStructs())
+			require.NoError(t, err)
+
+			first, err := MerkleRoot(wire, sha256.New)
+			require.NoError(t, err)
+
+			second, err := MerkleRoot(wire, sha256.New)
+			require.NoError(t, err)
+
+			assert.Equal(t, first, second)
+		}
+	})
+
+	t.Run("Sensitive", func(t *testing.T) {
+		// Pack and document wires with the same contents must have different roots
+		pack, err := Polorize(PathSigner{"alice", 1})
+		require.NoError(t, err)
+
+		doc, err := Polorize(PathSigner{"alice", 1}, DocStructs())
+		require.NoError(t, err)
+
+		changed, err := Polorize(PathSigner{"alice", 2})
+		require.NoError(t, err)
+
+		roots := make(map[string]struct{})
+
+		for _, wire := range [][]byte{pack, doc, changed} {
+			root, err := MerkleRoot(wire, sha256.New)
+			require.NoError(t, err)
+
+			roots[string(root)] = struct{}{}
+		}
+
+		assert.Len(t, roots, 3)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := MerkleRoot([]byte{14, 47, 6}, sha256.New)
+		assert.Error(t, err)
+	})
+}
+
+func TestMerkleProof(t *testing.T) {
+	object := PathObject{
+		Inputs: []PathInput{{"MOI", 100}, {"ETH", 200}, {"BTC", 300}},
+		Matrix: [][]string{{"a", "b"}, {"c"}},
+	}
+
+	object.Header.Height = 42
+	object.Header.Signer = PathSigner{"alice", 7}
+
+	wire, err := Polorize(object, DocStructs())
+	require.NoError(t, err)
+
+	root, err := MerkleRoot(wire, sha256.New)
+	require.NoError(t, err)
+
+	t.Run("Valid", func(t *testing.T) {
+		tests := []struct {
+			path     string
+			expected any
+		}{
+			{"Header.Height", uint64(42)},
+			{"Header.Signer.Address", "alice"},
+			{"Inputs[1].Amount", uint64(200)},
+			{"Inputs[2]", PathInput{"BTC", 300}},
+			{"Matrix[0][1]", "b"},
+			{"Matrix[1]", []string{"c"}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.path, func(t *testing.T) {
+				proof, err := MerkleProof(wire, test.path, sha256.New)
+				require.NoError(t, err)
+
+				require.NoError(t, VerifyProof(root, test.path, proof, sha256.New))
+
+				expected, err := Polorize(test.expected, DocStructs())
+				require.NoError(t, err)
+				assert.Equal(t, Any(expected), proof.Value)
+
+				// Proofs must survive being encoded
+				encoded, err := Polorize(proof)
+				require.NoError(t, err)
+
+				decoded := new(Proof)
+				require.NoError(t, Depolorize(decoded, encoded))
+				require.NoError(t, VerifyProof(root, test.path, *decoded, sha256.New))
+			})
+		}
+	})
+
+	t.Run("Pack", func(t *testing.T) {
+		pack, err := Polorize([]PathInput{{"MOI", 100}, {"ETH", 200}})
+		require.NoError(t, err)
+
+		packRoot, err := MerkleRoot(pack, sha256.New)
+		require.NoError(t, err)
+
+		proof, err := MerkleProof(pack, "[1][0]", sha256.New)
+		require.NoError(t, err)
+		require.NoError(t, VerifyProof(packRoot, "[1][0]", proof, sha256.New))
+
+		var asset string
+		require.NoError(t, Depolorize(&asset, proof.Value))
+		assert.Equal(t, "ETH", asset)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		proof, err := MerkleProof(wire, "Inputs[1].Amount", sha256.New)
+		require.NoError(t, err)
+
+		// Tampered value
+		tampered := proof
+		tampered.Value, _ = Polorize(uint64(2000))
+		assert.EqualError(t, VerifyProof(root, "Inputs[1].Amount", tampered, sha256.New),
+			"invalid merkle proof for path 'Inputs[1].Amount': root mismatch")
+
+		// Proof for a different path
+		assert.EqualError(t, VerifyProof(root, "Inputs[0].Amount", proof, sha256.New),
+			"invalid merkle proof for path 'Inputs[0].Amount': step mismatch at path 'Inputs[0]'")
+		assert.EqualError(t, VerifyProof(root, "Inputs[1]", proof, sha256.New),
+			"invalid merkle proof for path 'Inputs[1]': expected 2 steps, got 3")
+
+		// Tampered siblings
+		tampered = proof
+		tampered.Steps = append([]ProofStep{}, proof.Steps...)
+		tampered.Steps[1].Siblings = tampered.Steps[1].Siblings[1:]
+		assert.EqualError(t, VerifyProof(root, "Inputs[1].Amount", tampered, sha256.New),
+			"invalid merkle proof for path 'Inputs[1].Amount': insufficient siblings")
+
+		// Tampered positions and siblings must not prove a different element
+		pack, err := Polorize([]string{"a", "b", "c"})
+		require.NoError(t, err)
+
+		packRoot, err := MerkleRoot(pack, sha256.New)
+		require.NoError(t, err)
+
+		last, err := MerkleProof(pack, "[2]", sha256.New)
+		require.NoError(t, err)
+		require.NoError(t, VerifyProof(packRoot, "[2]", last, sha256.New))
+
+		forge := func(index, count int, siblings [][]byte) Proof {
+			return Proof{Value: last.Value, Steps: []ProofStep{{Wire: WirePack, Index: index, Count: count, Siblings: siblings}}}
+		}
+
+		first, err := MerkleProof(pack, "[0]", sha256.New)
+		require.NoError(t, err)
+
+		forgeries := []struct {
+			path  string
+			proof Proof
+		}{
+			{"[1]", forge(1, 2, last.Steps[0].Siblings)},
+			{"[1]", forge(1, 3, last.Steps[0].Siblings)},
+			{"[0]", forge(0, 1, nil)},
+			{"[2]", forge(2, 4, last.Steps[0].Siblings)},
+			{"[0]", forge(0, 3, first.Steps[0].Siblings)},
+			{"[2]", forge(2, 3, first.Steps[0].Siblings[1:])},
+		}
+
+		for _, forgery := range forgeries {
+			assert.Error(t, VerifyProof(packRoot, forgery.path, forgery.proof, sha256.New), forgery.proof.Steps[0])
+		}
+
+		// Different root
+		other, _ := MerkleRoot([]byte{0}, sha256.New)
+		assert.Error(t, VerifyProof(other, "Inputs[1].Amount", proof, sha256.New))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			path string
+			err  string
+		}{
+			{"Missing", "merkle value not found for path 'Missing'"},
+			{"Inputs[3]", "merkle value not found for path 'Inputs[3]'"},
+			{"[0]", "merkle value at path '' could not be indexed: " +
+				"incompatible wire: unexpected wiretype 'document'. expected one of: {pack}"},
+			{"Inputs.Asset", "merkle value at path 'Inputs' is not a document: " +
+				"incompatible wire: unexpected wiretype 'pack'. expected one of: {document}"},
+			{"Inputs[0].Asset.Name", "merkle value at path 'Inputs[0].Asset' is not a document: " +
+				"incompatible wire: unexpected wiretype 'word'. expected one of: {document}"},
+			{"Inputs..Asset", "malformed document path 'Inputs..Asset': empty key at position 7"},
+		}
+
+		for _, test := range tests {
+			_, err := MerkleProof(wire, test.path, sha256.New)
+			assert.EqualError(t, err, test.err, test.path)
+		}
+	})
+}
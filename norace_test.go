@@ -0,0 +1,7 @@
+//go:build !race
+
+package polo
+
+// raceEnabled is whether the tests are run with the race detector, which
+// randomly drops pooled objects and makes allocation counts unreliable.
+const raceEnabled = false
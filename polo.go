@@ -1,5 +1,12 @@
 package polo
 
+import (
+	"iter"
+	"reflect"
+	"slices"
+	"sync"
+)
+
 // Any is some raw POLO encoded data.
 // The data of Any can have any WireType
 type Any []byte
@@ -17,16 +24,116 @@ type Polorizable interface {
 // Accepts EncodingOptions to modify the encoding behaviour.
 // Returns an error if object is an unsupported type such as functions or channels.
 func Polorize(object any, options ...EncodingOptions) ([]byte, error) {
-	// Create a new polorizer
-	polorizer := NewPolorizer(options...)
+	return AppendPolorize(nil, object, options...)
+}
 
-	// Polorize the object
-	if err := polorizer.Polorize(object); err != nil {
-		return nil, err
+// AppendPolorize serializes an object into its POLO byte form and appends it to dst, returning the extended slice.
+// The size of the object is computed in advance (see SizeOf) so that dst is grown at most once, after which the
+// object is encoded in place into dst, with each compound value written directly after its parent instead of being
+// copied into it. Reusing dst across calls allows encoding without allocations.
+// Accepts EncodingOptions to modify the encoding behaviour.
+// Returns dst unmodified and an error if object is an unsupported type such as functions or channels.
+func AppendPolorize(dst []byte, object any, options ...EncodingOptions) ([]byte, error) {
+	return appendPolorizeValue(dst, reflect.ValueOf(object), options...)
+}
+
+// appendPolorizeValue serializes a reflected value into its POLO byte form and appends it to dst.
+// The wire is compressed if a Compressor is configured with the given EncodingOptions.
+// Returns dst unmodified and an error if the value cannot be serialized.
+func appendPolorizeValue(dst []byte, value reflect.Value, options ...EncodingOptions) ([]byte, error) {
+	// Obtain a polorizer from the pool and return it once done
+	polorizer := acquirePolorizer(options...)
+	defer polorizerPool.Put(polorizer)
+
+	if polorizer.cfg.compressor == nil {
+		return polorizer.appendValue(dst, value)
+	}
+
+	// Encode the wire and compress it
+	wire, err := polorizer.appendValue(nil, value)
+	if err != nil {
+		return dst, err
+	}
+
+	compressed, err := Compress(wire, polorizer.cfg.compressor)
+	if err != nil {
+		return dst, err
+	}
+
+	return append(dst, compressed...), nil
+}
+
+// appendValue serializes a reflected value into its POLO byte form and appends it to dst.
+// The value is encoded in place into dst with appendInPlace if possible, otherwise it is
+// encoded into the buffers of the Polorizer and copied into dst. The Polorizer must be empty.
+func (polorizer *Polorizer) appendValue(dst []byte, value reflect.Value) ([]byte, error) {
+	wire, ok, err := polorizer.appendInPlace(dst, value)
+	switch {
+	case err != nil:
+		return dst, err
+	case ok:
+		return wire, nil
 	}
 
-	// Return the bytes of the writebuffer
-	return polorizer.wb.bytes(), nil
+	if err = polorizer.polorizeValue(value); err != nil {
+		return dst, err
+	}
+
+	return polorizer.wb.appendBytes(dst), nil
+}
+
+// appendInPlace measures a reflected value into a layout with which it is encoded in place into dst.
+// Returns false if the value cannot be measured or if the layout does not match the encoded value,
+// in which case the value must be encoded without a layout. The Polorizer is left empty.
+func (polorizer *Polorizer) appendInPlace(dst []byte, value reflect.Value) ([]byte, bool, error) {
+	// Obtain a layout from the pool and return it once done
+	plan, _ := layoutPool.Get().(*layout)
+	defer func() {
+		plan.reset()
+		layoutPool.Put(plan)
+	}()
+
+	// Measure the value into the layout. Errors are not returned, so
+	// that the error of encoding the value without a layout is returned
+	sz := sizer{cfg: polorizer.cfg, plan: plan}
+	if err := sz.sizeValue(value); err != nil {
+		return dst, false, nil
+	}
+
+	// Encode the value in place after a head for its tag, which is always one byte
+	plan.wire = slices.Grow(dst, sz.sb.size())
+	polorizer.wb.reserve(&plan.wire, 1)
+	polorizer.plan = plan
+
+	defer func() {
+		polorizer.plan = nil
+		polorizer.Reset()
+	}()
+
+	if err := polorizer.polorizeValue(value); err != nil {
+		return dst, false, err
+	}
+
+	return plan.wire, polorizer.wb.filled() && !plan.failed, nil
+}
+
+// polorizerPool is a pool of Polorizer objects that are reused by the encoding functions.
+// Pooled Polorizer objects retain the capacity of their buffers (and nested buffers)
+var polorizerPool = sync.Pool{
+	New: func() any { return NewPolorizer() },
+}
+
+// acquirePolorizer returns an empty Polorizer from the pool with the given EncodingOptions applied.
+func acquirePolorizer(options ...EncodingOptions) *Polorizer {
+	polorizer, _ := polorizerPool.Get().(*Polorizer)
+	polorizer.Reset()
+
+	// Reset to a default wire config
+	polorizer.cfg = *defaultWireConfig()
+	// Apply any given options to the config
+	polorizer.cfg.apply(options...)
+
+	return polorizer
 }
 
 // Depolorizable is an interface for an object that deserialize its contents from a Depolorizer
@@ -50,3 +157,100 @@ func Depolorize(object any, data []byte, options ...EncodingOptions) error {
 
 	return nil
 }
+
+// Encode serializes an object of type T into its POLO byte form.
+// It is the typed counterpart of Polorize and avoids boxing the object into an interface.
+// Accepts EncodingOptions to modify the encoding behaviour.
+// Returns an error if T is an unsupported type such as functions or channels.
+func Encode[T any](object T, options ...EncodingOptions) ([]byte, error) {
+	// Reflect the object through its pointer, unwrapping it if T is an interface
+	value := reflect.ValueOf(&object).Elem()
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	wire, err := appendPolorizeValue(nil, value, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wire, nil
+}
+
+// Decode deserializes a POLO encoded byte slice into an object of type T and returns it.
+// It is the typed counterpart of Depolorize and does not require a pointer to the object.
+// A WireNull is decoded as the zero value of T. Accepts EncodingOptions to modify the decoding behaviour.
+// Returns an error if the wire cannot be parsed or cannot be decoded into T.
+func Decode[T any](data []byte, options ...EncodingOptions) (T, error) {
+	var object T
+
+	// Create a new depolorizer from the data
+	depolorizer, err := NewDepolorizer(data, options...)
+	if err != nil {
+		return object, err
+	}
+
+	// Depolorize the object directly, the pointer to it is always settable
+	if err = depolorizer.depolorizeInto(reflect.ValueOf(&object).Elem()); err != nil {
+		return object, err
+	}
+
+	return object, nil
+}
+
+// DecodeSlice deserializes a POLO encoded pack into a slice with elements of type T.
+// Equivalent to Decode[[]T], a WireNull is decoded as a nil slice.
+func DecodeSlice[T any](data []byte, options ...EncodingOptions) ([]T, error) {
+	return Decode[[]T](data, options...)
+}
+
+// DecodeMap deserializes a POLO encoded pack (or document) into a map with keys of type K and values of type V.
+// Equivalent to Decode[map[K]V], a WireNull is decoded as a nil map.
+func DecodeMap[K comparable, V any](data []byte, options ...EncodingOptions) (map[K]V, error) {
+	return Decode[map[K]V](data, options...)
+}
+
+// PackSeq returns an iterator over the elements of a POLO encoded pack, such as a slice or array.
+// Each element is decoded into an object of type T as the iteration proceeds, which allows large
+// packs to be processed without decoding them into a slice first. A WireNull is treated as an empty pack.
+// Accepts EncodingOptions to modify the decoding behaviour.
+//
+// If the wire cannot be unpacked or an element cannot be decoded, the error
+// is yielded along with the zero value of T and the iteration stops.
+func PackSeq[T any](wire []byte, options ...EncodingOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		// Create a new depolorizer from the wire
+		depolorizer, err := NewDepolorizer(wire, options...)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		// Nil pack has no elements
+		if depolorizer.IsNull() {
+			return
+		}
+
+		// Unpack the depolorizer to iterate over its elements
+		if depolorizer, err = depolorizer.Unpacked(); err != nil {
+			yield(zero, err)
+			return
+		}
+
+		for !depolorizer.Done() {
+			var element T
+
+			// Depolorize the next element
+			if err = depolorizer.Depolorize(&element); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			if !yield(element, nil) {
+				return
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"math/big"
 	"sort"
 	"testing"
@@ -395,6 +396,30 @@ func TestInteger(t *testing.T) {
 	})
 }
 
+func TestIntegerBounds(t *testing.T) {
+	for _, x := range []int64{math.MinInt64, math.MinInt64 + 1, math.MaxInt64, -1, 0} {
+		testSerialization(t, x)
+	}
+
+	testSerialization(t, int32(math.MinInt32))
+	testSerialization(t, int16(math.MinInt16))
+	testSerialization(t, int8(math.MinInt8))
+	testSerialization(t, uint64(math.MaxUint64))
+
+	wire, err := Polorize(int64(math.MinInt64))
+	require.Nil(t, err)
+	assert.Equal(t, []byte{4, 128, 0, 0, 0, 0, 0, 0, 0}, wire)
+
+	// Magnitudes beyond the bounds of int64 overflow
+	var x int64
+
+	err = Depolorize(&x, []byte{3, 128, 0, 0, 0, 0, 0, 0, 0})
+	assert.EqualError(t, err, "incompatible value error: overflow for signed integer")
+
+	err = Depolorize(&x, []byte{4, 128, 0, 0, 0, 0, 0, 0, 1})
+	assert.EqualError(t, err, "incompatible value error: overflow for signed integer")
+}
+
 type BoolObject struct {
 	A bool
 	B bool
@@ -689,6 +714,15 @@ func TestSequence(t *testing.T) {
 	})
 }
 
+type CompositeKey struct {
+	Kind  string
+	Index [2]int32
+	Final bool
+	Inner struct {
+		Epoch uint64
+	}
+}
+
 type MapObject struct {
 	A map[bool]string
 	B map[float32]uint64
@@ -701,6 +735,8 @@ type MapObject struct {
 	I map[[4]float32]uint64
 	J map[[2][2]string]string
 	K map[string]*string
+	L map[CompositeKey]uint64
+	M map[bool][2]bool
 }
 
 func TestMapping(t *testing.T) {
@@ -773,6 +809,47 @@ func TestMapping(t *testing.T) {
 		require.Nil(t, err)
 	})
 
+	t.Run("Struct Keyed Map", func(t *testing.T) {
+		var x map[CompositeKey]string
+
+		for i := 0; i < 10000; i++ {
+			f.Fuzz(&x)
+			testSerialization(t, x)
+		}
+	})
+
+	t.Run("Pointer Keyed Map", func(t *testing.T) {
+		a, b, c := "foo", "bar", "foo"
+
+		wire, err := Polorize(map[*string]int{&a: 1, &b: 2, nil: 3})
+		require.Nil(t, err)
+
+		// Encoding must be independent of pointer identity
+		rewire, err := Polorize(map[*string]int{nil: 3, &c: 1, &b: 2})
+		require.Nil(t, err)
+		require.Equal(t, wire, rewire)
+
+		decoded := make(map[*string]int)
+		require.Nil(t, Depolorize(&decoded, wire))
+		require.Len(t, decoded, 3)
+
+		for key, val := range decoded {
+			switch {
+			case key == nil:
+				require.Equal(t, 3, val)
+			case *key == "foo":
+				require.Equal(t, 1, val)
+			case *key == "bar":
+				require.Equal(t, 2, val)
+			}
+		}
+	})
+
+	t.Run("Unsortable Keyed Map", func(t *testing.T) {
+		_, err := Polorize(map[chan int]string{make(chan int): "foo", make(chan int): "bar"})
+		require.EqualError(t, err, "incompatible value error: cannot sort map keys of type chan int: unsupported key compare")
+	})
+
 	t.Run("MapObject", func(t *testing.T) {
 		var x MapObject
 
@@ -1498,14 +1575,14 @@ func TestMalformed(t *testing.T) {
 			[]byte{14, 78, 3, 3, 3, 3},
 			&IntegerObject{},
 			[]EncodingOptions{},
-			errors.New("load convert fail: missing load tag"),
+			MalformedLoadError{msg: "missing load tag"},
 		},
 		{
 			"malformed load tag for pack wire",
 			[]byte{14, 255, 128, 128, 128, 128, 128, 128, 128, 128, 127, 3, 3, 3, 3, 3},
 			&IntegerObject{},
 			[]EncodingOptions{},
-			errors.New("load convert fail: malformed tag: varint overflows 64-bit integer"),
+			MalformedLoadError{err: MalformedTagError{"varint overflows 64-bit integer"}},
 		},
 		{
 			"insufficient data for struct decode",
@@ -1519,35 +1596,35 @@ func TestMalformed(t *testing.T) {
 			[]byte{13, 175},
 			new(Document),
 			[]EncodingOptions{},
-			errors.New("load convert fail: malformed tag: varint terminated prematurely"),
+			MalformedLoadError{err: MalformedTagError{"varint terminated prematurely"}},
 		},
 		{
 			"malformed varint when decoding slice",
 			[]byte{14, 175},
 			new([]string),
 			[]EncodingOptions{},
-			errors.New("load convert fail: malformed tag: varint terminated prematurely"),
+			MalformedLoadError{err: MalformedTagError{"varint terminated prematurely"}},
 		},
 		{
 			"malformed varint when decoding array",
 			[]byte{14, 175},
 			new([2]float32),
 			[]EncodingOptions{},
-			errors.New("load convert fail: malformed tag: varint terminated prematurely"),
+			MalformedLoadError{err: MalformedTagError{"varint terminated prematurely"}},
 		},
 		{
 			"malformed varint when decoding mapping",
 			[]byte{14, 175},
 			new(map[uint64]string),
 			[]EncodingOptions{},
-			errors.New("load convert fail: malformed tag: varint terminated prematurely"),
+			MalformedLoadError{err: MalformedTagError{"varint terminated prematurely"}},
 		},
 		{
 			"malformed varint when decoding packed bytes",
 			[]byte{14, 175},
 			new([]byte),
 			[]EncodingOptions{PackedBytes()},
-			errors.New("load convert fail: malformed tag: varint terminated prematurely"),
+			MalformedLoadError{err: MalformedTagError{"varint terminated prematurely"}},
 		},
 		{
 			"insufficient data for document decode",
@@ -1646,6 +1723,10 @@ func TestMalformed(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			err := Depolorize(test.object, test.wire, test.options...)
 			assert.EqualError(t, err, test.err.Error(), "[%v] Input: %v", tno, test.wire)
+
+			// Malformed loads must be identifiable by their type
+			var malformedLoad MalformedLoadError
+			assert.Equal(t, errors.As(test.err, &malformedLoad), errors.As(err, &malformedLoad))
 		})
 	}
 }
@@ -1888,3 +1969,145 @@ func TestSpecialBytes(t *testing.T) {
 		})
 	})
 }
+
+func TestPackSeq(t *testing.T) {
+	t.Run("Elements", func(t *testing.T) {
+		wire, err := Polorize([]WordObject{{A: "foo"}, {A: "bar"}, {A: "baz"}})
+		require.Nil(t, err)
+
+		var elements []WordObject
+
+		for element, err := range PackSeq[WordObject](wire) {
+			require.Nil(t, err)
+
+			elements = append(elements, element)
+			if element.A == "bar" {
+				break
+			}
+		}
+
+		assert.Equal(t, []WordObject{{A: "foo"}, {A: "bar"}}, elements)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		for range PackSeq[string]([]byte{0}) {
+			t.Fatal("unexpected element")
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, err := range PackSeq[string]([]byte{6, 102, 111, 111}) {
+			assert.EqualError(t, err, "incompatible wire: unexpected wiretype 'word'. expected one of: {pack, document}")
+		}
+
+		wire, err := Polorize([]uint64{1, 2})
+		require.Nil(t, err)
+
+		var count int
+
+		for element, err := range PackSeq[string](wire) {
+			assert.Equal(t, "", element)
+			assert.EqualError(t, err, "incompatible wire: unexpected wiretype 'posint'. expected one of: {null, word}")
+
+			count++
+		}
+
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestEncodeDecode(t *testing.T) {
+	f := fuzz.New().NilChance(0.2).NumElements(0, 8)
+
+	t.Run("Equivalence", func(t *testing.T) {
+		var x MapObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+
+			expected, err := Polorize(x)
+			require.Nil(t, err)
+
+			wire, err := Encode(x)
+			require.Nil(t, err)
+			require.Equal(t, expected, wire)
+
+			decoded, err := Decode[MapObject](wire)
+			require.Nil(t, err)
+			require.Equal(t, x, decoded)
+		}
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		object := NestedObject{A: WordObject{A: "foo"}}
+
+		wire, err := Encode(object, DocStructs())
+		require.Nil(t, err)
+		assert.True(t, IsWireType(wire, WireDoc))
+
+		decoded, err := Decode[NestedObject](wire, DocStructs())
+		require.Nil(t, err)
+		assert.Equal(t, object, decoded)
+	})
+
+	t.Run("Interface", func(t *testing.T) {
+		var object any = "foo"
+
+		wire, err := Encode(object)
+		require.Nil(t, err)
+		assert.Equal(t, []byte{6, 102, 111, 111}, wire)
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		object := CustomEncodeObject{A: "foo", B: 300}
+
+		wire, err := Encode(object)
+		require.Nil(t, err)
+
+		decoded, err := Decode[CustomEncodeObject](wire)
+		require.Nil(t, err)
+		assert.Equal(t, object, decoded)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		decoded, err := Decode[*WordObject]([]byte{0})
+		require.Nil(t, err)
+		assert.Nil(t, decoded)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := Encode(make(chan int))
+		assert.EqualError(t, err, "incompatible value error: unsupported type: chan int [chan]")
+
+		_, err = Decode[uint64]([]byte{6, 102, 111, 111})
+		assert.EqualError(t, err, "incompatible wire: unexpected wiretype 'word'. expected one of: {null, posint}")
+
+		_, err = Decode[uint64](nil)
+		assert.EqualError(t, err, "malformed tag: varint terminated prematurely")
+	})
+
+	t.Run("Slice", func(t *testing.T) {
+		wire, err := Encode([]string{"foo", "bar"})
+		require.Nil(t, err)
+
+		decoded, err := DecodeSlice[string](wire)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"foo", "bar"}, decoded)
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		wire, err := Encode(map[string]uint64{"foo": 1, "bar": 2})
+		require.Nil(t, err)
+
+		decoded, err := DecodeMap[string, uint64](wire)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]uint64{"foo": 1, "bar": 2}, decoded)
+
+		wire, err = Encode(map[string]uint64{"foo": 1, "bar": 2}, DocStringMaps())
+		require.Nil(t, err)
+
+		decoded, err = DecodeMap[string, uint64](wire, DocStringMaps())
+		require.Nil(t, err)
+		assert.Equal(t, map[string]uint64{"foo": 1, "bar": 2}, decoded)
+	})
+}
@@ -0,0 +1,257 @@
+// Package polohttp implements helpers for exchanging POLO encoded content over net/http.
+//
+// Servers write responses with Write (always POLO) or Respond (negotiated between POLO
+// and JSON from the Accept header of the request) and read request bodies with Read.
+// Clients create requests with NewRequest, read responses with ReadResponse and can use
+// RoundTripper to advertise POLO as the preferred content type on every request.
+package polohttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sarvalabs/go-polo"
+)
+
+// Media types for content that can be exchanged
+const (
+	// ContentType is the media type for POLO encoded content
+	ContentType = "application/polo"
+	// ContentTypeJSON is the media type for JSON encoded content
+	ContentTypeJSON = "application/json"
+)
+
+// DefaultMaxBodySize is the maximum size of a body that is read if no size is given in Limits
+const DefaultMaxBodySize = 4 << 20
+
+// ErrUnsupportedMediaType is an error for when a body has a media type other than POLO or JSON
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// Limits are the safety limits for reading a body
+type Limits struct {
+	// MaxBodySize is the maximum size of the body in bytes. DefaultMaxBodySize is used if it is not positive.
+	// Reading a larger body fails with an *http.MaxBytesError.
+	MaxBodySize int64
+	// Options are the EncodingOptions for decoding a POLO body, such as
+	// polo.DisallowUnknownFields, polo.ValidateSchema or polo.Decompression
+	Options []polo.EncodingOptions
+}
+
+// maxBodySize returns the maximum size of the body for the Limits
+func (limits Limits) maxBodySize() int64 {
+	if limits.MaxBodySize <= 0 {
+		return DefaultMaxBodySize
+	}
+
+	return limits.MaxBodySize
+}
+
+// Write serializes an object into its POLO byte form and writes it as the response with the given status.
+// The Content-Type of the response is set to ContentType. Accepts EncodingOptions to modify the encoding
+// behaviour of the object. Returns an error without writing the response if the object cannot be encoded.
+func Write(w http.ResponseWriter, status int, object any, options ...polo.EncodingOptions) error {
+	wire, err := polo.Polorize(object, options...)
+	if err != nil {
+		return err
+	}
+
+	return writeBody(w, status, ContentType, wire)
+}
+
+// Respond writes an object as the response with the given status, encoded as POLO or JSON based on the Accept
+// header of the request (see Negotiate). EncodingOptions only apply if the object is encoded as POLO.
+// Returns an error without writing the response if the object cannot be encoded.
+func Respond(w http.ResponseWriter, r *http.Request, status int, object any, options ...polo.EncodingOptions) error {
+	if Negotiate(r) == ContentTypeJSON {
+		data, err := json.Marshal(object)
+		if err != nil {
+			return err
+		}
+
+		return writeBody(w, status, ContentTypeJSON, data)
+	}
+
+	return Write(w, status, object, options...)
+}
+
+// writeBody writes some data as the response with the given status and content type
+func writeBody(w http.ResponseWriter, status int, contentType string, data []byte) error {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(status)
+
+	_, err := w.Write(data)
+
+	return err
+}
+
+// Negotiate returns the media type for a response to the request based on its Accept header,
+// which is either ContentType or ContentTypeJSON. Quality values are respected and the most
+// specific media range that matches a media type determines its quality, so an explicit q=0
+// is not overridden by a wildcard. Media types that are only matched by a wildcard are less
+// preferred than those that are listed. POLO is preferred when both media types are equally
+// acceptable or if the header is absent.
+func Negotiate(r *http.Request) string {
+	accept := r.Header.Values("Accept")
+	if len(accept) == 0 {
+		return ContentType
+	}
+
+	// Collect the quality of each listed media range
+	qualities := make(map[string]float64)
+
+	for _, value := range accept {
+		for _, entry := range strings.Split(value, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(entry))
+			if err != nil {
+				continue
+			}
+
+			// Parse the quality of the media type, which defaults to 1
+			quality := 1.0
+			if q, ok := params["q"]; ok {
+				if quality, err = strconv.ParseFloat(q, 64); err != nil {
+					continue
+				}
+			}
+
+			if existing, ok := qualities[mediaType]; !ok || quality > existing {
+				qualities[mediaType] = quality
+			}
+		}
+	}
+
+	if acceptQuality(qualities, ContentTypeJSON) > acceptQuality(qualities, ContentType) {
+		return ContentTypeJSON
+	}
+
+	return ContentType
+}
+
+// acceptQuality returns the quality of an application media type from the qualities of the listed
+// media ranges. The most specific range that matches the media type is used, with the quality of
+// wildcard ranges halved. Returns 0 if the media type is not matched by any of the ranges.
+func acceptQuality(qualities map[string]float64, mediaType string) float64 {
+	if quality, ok := qualities[mediaType]; ok {
+		return quality
+	}
+
+	for _, wildcard := range []string{"application/*", "*/*"} {
+		if quality, ok := qualities[wildcard]; ok {
+			return quality / 2
+		}
+	}
+
+	return 0
+}
+
+// Read decodes the body of a request into an object which must be a pointer. The body is decoded as JSON
+// if the Content-Type of the request is ContentTypeJSON and as POLO if it is ContentType or absent.
+// Returns ErrUnsupportedMediaType for any other Content-Type and an *http.MaxBytesError if the body
+// exceeds the size limit.
+func Read(r *http.Request, object any, limits Limits) error {
+	return readBody(r.Header.Get("Content-Type"), r.Body, object, limits)
+}
+
+// readBody decodes a body with the given content type into an object, applying the given limits
+func readBody(contentType string, body io.ReadCloser, object any, limits Limits) error {
+	if body == nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	// Determine the media type of the body
+	mediaType := ContentType
+	if contentType != "" {
+		parsed, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrUnsupportedMediaType, contentType)
+		}
+
+		mediaType = parsed
+	}
+
+	if mediaType != ContentType && mediaType != ContentTypeJSON {
+		return fmt.Errorf("%w: %v", ErrUnsupportedMediaType, mediaType)
+	}
+
+	// Read the body up to the size limit
+	data, err := io.ReadAll(http.MaxBytesReader(nil, body, limits.maxBodySize()))
+	if err != nil {
+		return err
+	}
+
+	if mediaType == ContentTypeJSON {
+		return json.Unmarshal(data, object)
+	}
+
+	return polo.Depolorize(object, data, limits.Options...)
+}
+
+// NewRequest returns a new http.Request with an object serialized into its POLO byte form as the body.
+// The Content-Type and Accept headers of the request are set to ContentType. Accepts EncodingOptions
+// to modify the encoding behaviour of the object.
+func NewRequest(
+	ctx context.Context, method, url string, object any, options ...polo.EncodingOptions,
+) (*http.Request, error) {
+	wire, err := polo.Polorize(object, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(wire))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", ContentType)
+	request.Header.Set("Accept", ContentType)
+
+	return request, nil
+}
+
+// ReadResponse decodes the body of a response into an object which must be a pointer and closes the body.
+// The body is decoded based on the Content-Type of the response, similar to Read. The status of the response
+// is not checked and must be handled by the caller.
+func ReadResponse(response *http.Response, object any, limits Limits) error {
+	if response.Body != nil {
+		defer response.Body.Close()
+	}
+
+	return readBody(response.Header.Get("Content-Type"), response.Body, object, limits)
+}
+
+// RoundTripper is an http.RoundTripper that sets the Accept header of requests to
+// prefer POLO responses, if the request does not already have an Accept header.
+type RoundTripper struct {
+	// Base is the http.RoundTripper used to perform requests. http.DefaultTransport is used if it is nil.
+	Base http.RoundTripper
+}
+
+// RoundTrip performs a single HTTP transaction with the Accept header set to prefer POLO.
+// The given request is not modified. Implements the http.RoundTripper interface for RoundTripper.
+func (transport RoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := transport.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if request.Header.Get("Accept") == "" {
+		request = request.Clone(request.Context())
+		request.Header.Set("Accept", ContentType+", "+ContentTypeJSON+";q=0.5")
+	}
+
+	return base.RoundTrip(request)
+}
+
+// NewClient returns a new http.Client that uses a RoundTripper over http.DefaultTransport.
+func NewClient() *http.Client {
+	return &http.Client{Transport: RoundTripper{}}
+}
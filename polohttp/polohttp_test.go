@@ -0,0 +1,209 @@
+package polohttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sarvalabs/go-polo"
+)
+
+type Fruit struct {
+	Name  string   `json:"name"`
+	Cost  int      `json:"cost"`
+	Alias []string `json:"alias"`
+}
+
+func TestWrite(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	require.NoError(t, Write(recorder, http.StatusCreated, Fruit{"orange", 300, nil}))
+
+	expected, err := polo.Polorize(Fruit{"orange", 300, nil})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+	assert.Equal(t, expected, recorder.Body.Bytes())
+
+	// Nothing is written if the object cannot be encoded
+	recorder = httptest.NewRecorder()
+	require.Error(t, Write(recorder, http.StatusOK, make(chan int)))
+	assert.Zero(t, recorder.Body.Len())
+	assert.Empty(t, recorder.Header().Get("Content-Type"))
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept   []string
+		expected string
+	}{
+		{nil, ContentType},
+		{[]string{"application/polo"}, ContentType},
+		{[]string{"application/json"}, ContentTypeJSON},
+		{[]string{"application/json, application/polo"}, ContentType},
+		{[]string{"application/polo;q=0.5, application/json"}, ContentTypeJSON},
+		{[]string{"application/json;q=0.9, */*"}, ContentTypeJSON},
+		{[]string{"text/html", "application/json;q=0.1"}, ContentTypeJSON},
+		{[]string{"*/*"}, ContentType},
+		{[]string{"text/html"}, ContentType},
+		{[]string{"application/json;q=bad"}, ContentType},
+		{[]string{"application/polo;q=0, */*"}, ContentTypeJSON},
+		{[]string{"application/json;q=0, application/*"}, ContentType},
+		{[]string{"application/*;q=0, */*"}, ContentType},
+		{[]string{"application/*;q=0.2", "*/*;q=1", "application/json;q=0.15"}, ContentTypeJSON},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		for _, accept := range test.accept {
+			request.Header.Add("Accept", accept)
+		}
+
+		assert.Equal(t, test.expected, Negotiate(request), test.accept)
+	}
+}
+
+func TestRespond(t *testing.T) {
+	fruit := Fruit{"orange", 300, []string{"tangerine"}}
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept", "application/json")
+
+	recorder := httptest.NewRecorder()
+	require.NoError(t, Respond(recorder, request, http.StatusOK, fruit))
+	assert.Equal(t, ContentTypeJSON, recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"name":"orange","cost":300,"alias":["tangerine"]}`, recorder.Body.String())
+
+	request.Header.Set("Accept", "application/polo")
+
+	recorder = httptest.NewRecorder()
+	require.NoError(t, Respond(recorder, request, http.StatusOK, fruit, polo.DocStructs()))
+	assert.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+
+	expected, err := polo.Polorize(fruit, polo.DocStructs())
+	require.NoError(t, err)
+	assert.Equal(t, expected, recorder.Body.Bytes())
+}
+
+func TestRead(t *testing.T) {
+	wire, err := polo.Polorize(Fruit{"orange", 300, nil})
+	require.NoError(t, err)
+
+	t.Run("POLO", func(t *testing.T) {
+		for _, contentType := range []string{"", ContentType, ContentType + "; charset=binary"} {
+			request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(wire))
+			if contentType != "" {
+				request.Header.Set("Content-Type", contentType)
+			}
+
+			fruit := new(Fruit)
+			require.NoError(t, Read(request, fruit, Limits{}))
+			assert.Equal(t, Fruit{"orange", 300, nil}, *fruit)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"apple","cost":5}`))
+		request.Header.Set("Content-Type", ContentTypeJSON)
+
+		fruit := new(Fruit)
+		require.NoError(t, Read(request, fruit, Limits{}))
+		assert.Equal(t, Fruit{"apple", 5, nil}, *fruit)
+	})
+
+	t.Run("Body Limit", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(wire))
+
+		var maxBytesErr *http.MaxBytesError
+
+		err := Read(request, new(Fruit), Limits{MaxBodySize: 4})
+		require.True(t, errors.As(err, &maxBytesErr), err)
+		assert.Equal(t, int64(4), maxBytesErr.Limit)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		doc := make(polo.Document)
+		require.NoError(t, doc.Set("Name", "orange"))
+		require.NoError(t, doc.Set("Color", "orange"))
+
+		request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(doc.Bytes()))
+		err := Read(request, new(Fruit), Limits{Options: []polo.EncodingOptions{
+			polo.DocStructs(), polo.DisallowUnknownFields(),
+		}})
+		assert.EqualError(t, err, "incompatible wire: struct [polohttp.Fruit]: unknown document keys [Color]")
+	})
+
+	t.Run("Unsupported Media Type", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(wire))
+		request.Header.Set("Content-Type", "text/plain")
+
+		err := Read(request, new(Fruit), Limits{})
+		assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+		assert.EqualError(t, err, "unsupported media type: text/plain")
+	})
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fruit := new(Fruit)
+		if err := Read(r, fruit, Limits{}); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fruit.Cost *= 2
+		_ = Respond(w, r, http.StatusOK, fruit)
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	client.Transport = RoundTripper{Base: server.Client().Transport}
+
+	t.Run("POLO", func(t *testing.T) {
+		request, err := NewRequest(context.Background(), http.MethodPost, server.URL, Fruit{"orange", 300, nil})
+		require.NoError(t, err)
+
+		response, err := client.Do(request)
+		require.NoError(t, err)
+		assert.Equal(t, ContentType, response.Header.Get("Content-Type"))
+
+		fruit := new(Fruit)
+		require.NoError(t, ReadResponse(response, fruit, Limits{}))
+		assert.Equal(t, Fruit{"orange", 600, nil}, *fruit)
+	})
+
+	t.Run("Accept", func(t *testing.T) {
+		// The round tripper sets the Accept header if it is absent
+		wire, err := polo.Polorize(Fruit{"apple", 1, nil})
+		require.NoError(t, err)
+
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, bytes.NewReader(wire))
+		require.NoError(t, err)
+
+		response, err := client.Do(request)
+		require.NoError(t, err)
+		assert.Equal(t, ContentType, response.Header.Get("Content-Type"))
+		assert.Empty(t, request.Header.Get("Accept"))
+		require.NoError(t, response.Body.Close())
+
+		// The round tripper respects an existing Accept header
+		request, err = http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, bytes.NewReader(wire))
+		require.NoError(t, err)
+		request.Header.Set("Accept", ContentTypeJSON)
+
+		response, err = client.Do(request)
+		require.NoError(t, err)
+		assert.Equal(t, ContentTypeJSON, response.Header.Get("Content-Type"))
+
+		fruit := new(Fruit)
+		require.NoError(t, ReadResponse(response, fruit, Limits{}))
+		assert.Equal(t, Fruit{"apple", 2, nil}, *fruit)
+	})
+}
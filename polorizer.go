@@ -6,7 +6,6 @@ import (
 	"math"
 	"math/big"
 	"reflect"
-	"sort"
 )
 
 // Polorizer is an encoding buffer that can sequentially polorize objects into it.
@@ -14,6 +13,14 @@ import (
 type Polorizer struct {
 	wb  *writebuffer
 	cfg wireConfig
+
+	// plan is the layout that the Polorizer encodes in place with, if any.
+	// It is shared with all the nested Polorizers while encoding in place.
+	plan *layout
+
+	// nest is a reusable Polorizer for encoding compound values.
+	// It is created lazily and retained across calls to Reset.
+	nest *Polorizer
 }
 
 // NewPolorizer creates a new Polorizer.
@@ -27,6 +34,13 @@ func NewPolorizer(options ...EncodingOptions) *Polorizer {
 	return &Polorizer{wb: &writebuffer{}, cfg: *config}
 }
 
+// Reset clears the contents of the Polorizer so that it can be reused to encode other objects.
+// The configuration of the Polorizer and the capacity of its internal buffers are retained.
+// Byte slices returned by the Polorizer before the reset remain valid.
+func (polorizer *Polorizer) Reset() {
+	polorizer.wb.reset()
+}
+
 // Bytes returns the contents of the Polorizer as bytes.
 //   - If no objects were polorized, it returns a WireNull wire
 //   - If only one object was polorized, it returns the contents directly
@@ -42,15 +56,29 @@ func (polorizer Polorizer) Bytes() []byte {
 	}
 }
 
+// Len returns the number of bytes in the contents of the Polorizer, i.e, the length of Bytes().
+// The length is computed from the buffered elements without collapsing them into bytes.
+func (polorizer Polorizer) Len() int {
+	switch polorizer.wb.counter {
+	case 0:
+		return 1
+	case 1:
+		return polorizer.wb.size()
+	default:
+		// WirePack tag (offset is always 0) and the load
+		return 1 + polorizer.wb.loadSize()
+	}
+}
+
 // Packed returns the contents of the Polorizer as bytes after packing it and tagging with WirePack.
 func (polorizer Polorizer) Packed() []byte {
-	// Declare a new writebuffer
-	var wb writebuffer
-	// Write the contents of the polorized buffer
-	// into the writebuffer and tag with WirePack
-	wb.write(WirePack, polorizer.wb.load())
+	// Allocate for the WirePack tag (offset is always 0) and the load
+	packed := make([]byte, 0, 1+polorizer.wb.loadSize())
+	// Write the WirePack tag followed by the contents
+	// of the polorized buffer as a load
+	packed = append(packed, byte(WirePack))
 
-	return wb.bytes()
+	return polorizer.wb.appendLoad(packed)
 }
 
 // Polorize encodes a value into the Polorizer.
@@ -211,7 +239,7 @@ func (polorizer *Polorizer) PolorizePacked(pack *Polorizer) {
 	}
 
 	// Encode pack load contents as a WirePack
-	polorizer.wb.write(WirePack, pack.wb.load())
+	polorizer.wb.writeLoad(WirePack, pack.wb)
 }
 
 // PolorizeDocument encodes a Document into the Polorizer.
@@ -224,16 +252,10 @@ func (polorizer *Polorizer) PolorizeDocument(document Document) {
 		return
 	}
 
-	// Collect all the document keys
-	keys := make([]string, 0, document.Size())
-	for key := range document {
-		keys = append(keys, key)
-	}
-
-	// Sort the document keys
-	sort.Strings(keys)
-	// Create a new polorizer for the document elements
-	documentWire := NewPolorizer(inheritCfg(polorizer.cfg))
+	// Collect all the document keys in sorted order
+	keys := document.Keys()
+	// Obtain a polorizer for the document elements
+	documentWire := polorizer.nested()
 
 	// Serialize each key (string) and value (bytes)
 	for _, key := range keys {
@@ -245,7 +267,7 @@ func (polorizer *Polorizer) PolorizeDocument(document Document) {
 
 	// Wrap the document polorizer contents as a WireLoad and
 	// write to the Polorizer with the WireDoc tag
-	polorizer.wb.write(WireDoc, documentWire.wb.load())
+	polorizer.wb.writeLoad(WireDoc, documentWire.wb)
 }
 
 func (polorizer *Polorizer) polorizeStructIntoDoc(value reflect.Value) (Document, error) {
@@ -256,25 +278,31 @@ func (polorizer *Polorizer) polorizeStructIntoDoc(value reflect.Value) (Document
 
 	// For each struct field that is exported and not skipped, encode
 	// the value and set it with the field name (or custom field key)
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-
+	for i, tag := range fieldTags(t) {
 		// Skip the field if it is not exported or if it
 		// is manually tagged to be skipped with a '-' tag
-		tag := field.Tag.Get("polo")
-		if !field.IsExported() || tag == "-" {
+		if tag.skip || tag.unknown {
 			continue
 		}
 
-		// Determine doc key for struct field. Field name is used
-		// directly if there is no provided in the polo tag.
-		fieldName := field.Name
-		if tag != "" {
-			fieldName = tag
+		if err := doc.Set(tag.name, value.Field(i).Interface(), inheritCfg(polorizer.cfg)); err != nil {
+			return nil, fmt.Errorf("could not encode into document: %w", err)
 		}
+	}
 
-		if err := doc.Set(fieldName, value.Field(i).Interface(), inheritCfg(polorizer.cfg)); err != nil {
-			return nil, fmt.Errorf("could not encode into document: %w", err)
+	// Find the field that collects unknown document keys
+	unknown, err := unknownField(t)
+	if err != nil {
+		return nil, err
+	}
+
+	// Merge the unknown document keys back into the document.
+	// Keys that are already set by a struct field take precedence.
+	if unknown != -1 {
+		for key, data := range value.Field(unknown).Interface().(Document) { //nolint:forcetypeassert
+			if !doc.Has(key) {
+				doc.SetRaw(key, data)
+			}
 		}
 	}
 
@@ -305,18 +333,47 @@ func (polorizer *Polorizer) polorizeInner(inner *Polorizer) {
 		return
 	}
 
-	// Collapse the inner polorizer into its bytes
-	// This will also resolve whether the polorizer is a packed wire
-	buffer, _ := newreadbuffer(inner.Bytes())
+	// Write the inner polorizer contents based on the number of elements, this resolves
+	// whether the polorizer is a packed wire in the same way as collapsing it with Bytes()
+	switch inner.wb.counter {
+	case 0:
+		polorizer.PolorizeNull()
+	case 1:
+		// A single element always has a one byte tag with zero offset
+		polorizer.wb.write(WireType(inner.wb.head[0]&15), inner.wb.body)
+	default:
+		polorizer.wb.writeLoad(WirePack, inner.wb)
+	}
+}
+
+// nested returns the reusable Polorizer for encoding a compound value within the Polorizer.
+// The returned Polorizer is empty and inherits the configuration of the Polorizer.
+// It is only valid until the next call to nested, after which it is cleared for reuse.
+// Nothing else may be encoded into the Polorizer until the nested Polorizer is written into it.
+func (polorizer *Polorizer) nested() *Polorizer {
+	if polorizer.nest == nil {
+		polorizer.nest = &Polorizer{wb: &writebuffer{}}
+	}
 
-	// Write the read buffer contents
-	polorizer.wb.write(buffer.wire, buffer.data)
+	polorizer.nest.Reset()
+	polorizer.nest.cfg, polorizer.nest.plan = polorizer.cfg, polorizer.plan
+
+	// If the Polorizer writes in place, the nested Polorizer writes in place after it, with
+	// a head of the size recorded for the compound value. If there is no such record, it is
+	// encoded into its own buffers and copied into the Polorizer instead.
+	if polorizer.wb.out != nil {
+		if head, ok := polorizer.plan.nextHead(); ok {
+			polorizer.nest.wb.reserveLoad(polorizer.wb.out, head)
+		}
+	}
+
+	return polorizer.nest
 }
 
 // polorizeByteAsPack encodes a []byte as WirePack
 func (polorizer *Polorizer) polorizeByteAsPack(bytes []byte) {
-	// Create a polorizer for the byte pack
-	pack := NewPolorizer(inheritCfg(polorizer.cfg))
+	// Obtain a polorizer for the byte pack
+	pack := polorizer.nested()
 	// Write each byte as a WirePosInt
 	for _, elem := range bytes {
 		pack.wb.write(WirePosInt, []byte{elem})
@@ -342,7 +399,7 @@ func (polorizer *Polorizer) polorizeByteArrayValue(value reflect.Value) {
 // polorizeArrayValue accepts a reflect.Value and encodes it into the Polorizer.
 // The value must be an array or slice and is encoded as element pack encoded data.
 func (polorizer *Polorizer) polorizeArrayValue(value reflect.Value) error {
-	array := NewPolorizer(inheritCfg(polorizer.cfg))
+	array := polorizer.nested()
 
 	// Serialize each element into the writebuffer
 	for i := 0; i < value.Len(); i++ {
@@ -363,9 +420,13 @@ func (polorizer *Polorizer) polorizeMapValue(value reflect.Value) error {
 	// Check if the map's key type is string AND the encoding
 	// config expects for string maps to be encoded as documents
 	if polorizer.cfg.docStrMaps && value.Type().Key().Kind() == reflect.String {
-		doc, err := polorizer.polorizeStrMapIntoDoc(value)
-		if err != nil {
-			return err
+		// Reuse the document if it was recorded while measuring the value
+		doc, ok := polorizer.plan.nextDoc(value.Type())
+		if !ok {
+			var err error
+			if doc, err = polorizer.polorizeStrMapIntoDoc(value); err != nil {
+				return err
+			}
 		}
 
 		polorizer.PolorizeDocument(doc)
@@ -373,12 +434,33 @@ func (polorizer *Polorizer) polorizeMapValue(value reflect.Value) error {
 		return nil
 	}
 
+	// Reuse the sorted entries of the map if they were recorded while measuring it
+	if entries, ok := polorizer.plan.nextEntries(value); ok {
+		mapping := polorizer.nested()
+		for _, entry := range entries {
+			if err := mapping.polorizeValue(entry); err != nil {
+				return err
+			}
+		}
+
+		polorizer.PolorizePacked(mapping)
+
+		return nil
+	}
+
 	// Sort the map keys
 	keys := value.MapKeys()
-	sort.Slice(keys, ValueSort(keys))
+	if err := SortKeys(keys); err != nil {
+		return IncompatibleValueError{fmt.Sprintf("cannot sort map keys of type %v: %v", value.Type().Key(), err)}
+	}
 
-	// Create a new polorizer for the map elements
-	mapping := NewPolorizer(inheritCfg(polorizer.cfg))
+	// Order any distinct keys that compare as equal by their encoded forms
+	if err := sortKeyTies(value, keys, polorizer.cfg); err != nil {
+		return err
+	}
+
+	// Obtain a polorizer for the map elements
+	mapping := polorizer.nested()
 	// Serialize each key and its value into the polorizer
 	for _, k := range keys {
 		// Polorize the key into the buffer
@@ -401,10 +483,13 @@ func (polorizer *Polorizer) polorizeMapValue(value reflect.Value) error {
 func (polorizer *Polorizer) polorizeStructValue(value reflect.Value) error {
 	// Check if the encoder config specifies to encode structs as documents
 	if polorizer.cfg.docStructs {
-		// Encode the struct into a Document
-		doc, err := polorizer.polorizeStructIntoDoc(value)
-		if err != nil {
-			return err
+		// Encode the struct into a Document, unless it was recorded while measuring the value
+		doc, ok := polorizer.plan.nextDoc(value.Type())
+		if !ok {
+			var err error
+			if doc, err = polorizer.polorizeStructIntoDoc(value); err != nil {
+				return err
+			}
 		}
 
 		// Flatten the document into the encoding buffer
@@ -416,14 +501,12 @@ func (polorizer *Polorizer) polorizeStructValue(value reflect.Value) error {
 	// Get the Type of the value
 	t := value.Type()
 
-	structure := NewPolorizer(inheritCfg(polorizer.cfg))
+	structure := polorizer.nested()
 	// Serialize each field into the writebuffer
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-
+	for i, tag := range fieldTags(t) {
 		// Skip the field if it is not exported or if it
 		// is manually tagged to be skipped with a '-' tag
-		if !field.IsExported() || field.Tag.Get("polo") == "-" {
+		if tag.skip || tag.rest {
 			continue
 		}
 
@@ -432,6 +515,22 @@ func (polorizer *Polorizer) polorizeStructValue(value reflect.Value) error {
 		}
 	}
 
+	// Find the field that collects trailing pack elements
+	rest, err := restField(t)
+	if err != nil {
+		return err
+	}
+
+	// Serialize the trailing elements after all the other fields
+	if rest != -1 {
+		elements := value.Field(rest)
+		for i := 0; i < elements.Len(); i++ {
+			if err = structure.polorizeValue(elements.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+
 	polorizer.PolorizePacked(structure)
 
 	return nil
@@ -440,6 +539,12 @@ func (polorizer *Polorizer) polorizeStructValue(value reflect.Value) error {
 // polorizePolorizable accepts a reflect.Value and encodes it into the Polorizer.
 // The value must implement the Polorizable interface.
 func (polorizer *Polorizer) polorizePolorizable(value reflect.Value) error {
+	// Reuse the output of the Polorize method if it was recorded while measuring the value
+	if inner, ok := polorizer.plan.nextInner(value.Type()); ok {
+		polorizer.polorizeInner(inner)
+		return nil
+	}
+
 	// Call the Polorize method of Polorizable (returns a Polorizer and an error)
 	outputs := value.MethodByName("Polorize").Call([]reflect.Value{})
 	if !outputs[1].IsNil() {
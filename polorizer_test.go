@@ -3,8 +3,10 @@ package polo
 import (
 	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 
+	fuzz "github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -252,3 +254,186 @@ func TestPolorizer_polorizerInner(t *testing.T) {
 	assert.Equal(t, []byte{14, 79, 0, 3, 19, 62, 5, 1, 44, 47, 3, 35, 1, 44, 250}, polorizer.Bytes())
 	assert.Equal(t, []byte{14, 79, 0, 3, 19, 62, 5, 1, 44, 47, 3, 35, 1, 44, 250}, polorizer.Packed())
 }
+
+func TestPolorizer_Reset(t *testing.T) {
+	polorizer := NewPolorizer()
+	require.Nil(t, polorizer.Polorize([]string{"foo", "bar"}))
+
+	wire := polorizer.Bytes()
+	assert.Equal(t, []byte{14, 47, 6, 54, 102, 111, 111, 98, 97, 114}, wire)
+
+	polorizer.Reset()
+	assert.Equal(t, []byte{0}, polorizer.Bytes())
+
+	// Previously returned bytes must not be overwritten by reuse
+	require.Nil(t, polorizer.Polorize([]string{"boo", "baz"}))
+	assert.Equal(t, []byte{14, 47, 6, 54, 98, 111, 111, 98, 97, 122}, polorizer.Bytes())
+	assert.Equal(t, []byte{14, 47, 6, 54, 102, 111, 111, 98, 97, 114}, wire)
+
+	if raceEnabled {
+		t.Skip("allocation counts are unreliable with the race detector")
+	}
+
+	// Nested buffers are reused once the Polorizer is warmed up
+	allocs := testing.AllocsPerRun(100, func() {
+		polorizer.Reset()
+		polorizer.PolorizeUint(300)
+		polorizer.PolorizePacked(polorizer.nested())
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestAppendPolorize(t *testing.T) {
+	object := MixedObject{
+		A: "Sins & Virtues",
+		B: 567822,
+		C: []string{"pride", "greed", "lust", "gluttony", "envy", "wrath", "sloth"},
+		D: map[string]string{"bravery": "piety", "friendship": "chastity"},
+		E: 45.23,
+	}
+
+	expected, err := Polorize(object)
+	require.Nil(t, err)
+
+	t.Run("Append", func(t *testing.T) {
+		wire, err := AppendPolorize([]byte{1, 2, 3}, object)
+		require.Nil(t, err)
+		assert.Equal(t, append([]byte{1, 2, 3}, expected...), wire)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		documented, err := Polorize(object, DocStructs())
+		require.Nil(t, err)
+
+		wire, err := AppendPolorize(nil, object, DocStructs())
+		require.Nil(t, err)
+		assert.Equal(t, documented, wire)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		dst := []byte{1, 2, 3}
+
+		wire, err := AppendPolorize(dst, make(chan int))
+		assert.EqualError(t, err, "incompatible value error: unsupported type: chan int [chan]")
+		assert.Equal(t, dst, wire)
+	})
+
+	t.Run("Allocations", func(t *testing.T) {
+		if raceEnabled {
+			t.Skip("allocation counts are unreliable with the race detector")
+		}
+
+		value := &[][]uint64{{1, 2}, {3, 4}}
+
+		// Warm up the pool of polorizers
+		dst, err := AppendPolorize(nil, value)
+		require.Nil(t, err)
+
+		// A nil destination is allocated exactly once
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = AppendPolorize(nil, value)
+		})
+		assert.Equal(t, float64(1), allocs)
+
+		// A reused destination does not need to be allocated
+		allocs = testing.AllocsPerRun(100, func() {
+			dst, _ = AppendPolorize(dst[:0], value)
+		})
+		assert.Zero(t, allocs)
+	})
+}
+
+// testAppendInPlace verifies that a given object is encoded in place by appendInPlace
+// and that its wire matches the wire from a Polorizer that does not encode in place
+func testAppendInPlace[T any](t *testing.T, x T, options ...EncodingOptions) {
+	t.Helper()
+
+	polorizer := NewPolorizer(options...)
+	require.Nil(t, polorizer.Polorize(x))
+
+	wire, ok, err := acquirePolorizer(options...).appendInPlace([]byte{1, 2}, reflect.ValueOf(x))
+	require.Nil(t, err)
+	require.True(t, ok, "Not In Place. Input: %v", x)
+	require.Equal(t, append([]byte{1, 2}, polorizer.Bytes()...), wire, "Wire Mismatch. Input: %v", x)
+}
+
+func TestAppendInPlace(t *testing.T) {
+	f := fuzz.New().NumElements(0, 8).NilChance(0.2).Funcs(fuzzAny, fuzzRaw)
+
+	t.Run("SequenceObject", func(t *testing.T) {
+		var x SequenceObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testAppendInPlace(t, x)
+			testAppendInPlace(t, x, PackedBytes())
+		}
+	})
+
+	t.Run("MapObject", func(t *testing.T) {
+		var x MapObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testAppendInPlace(t, x)
+			testAppendInPlace(t, x, DocStringMaps())
+		}
+	})
+
+	t.Run("NestedObject", func(t *testing.T) {
+		var x NestedObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testAppendInPlace(t, x)
+			testAppendInPlace(t, x, DocStructs())
+		}
+	})
+
+	t.Run("AnyObject", func(t *testing.T) {
+		var x AnyObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testAppendInPlace(t, x)
+		}
+	})
+
+	t.Run("Polorizable", func(t *testing.T) {
+		var x []CustomEncodeObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testAppendInPlace(t, x)
+			testAppendInPlace(t, map[string][]CustomEncodeObject{"objects": x}, DocStringMaps())
+		}
+	})
+
+	t.Run("Document", func(t *testing.T) {
+		var x map[string]Raw
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testAppendInPlace(t, Document(x))
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		// A head that is reserved with the wrong size is not filled by the tags of the value
+		polorizer := NewPolorizer()
+		polorizer.plan = &layout{heads: []int{3}}
+
+		var wire []byte
+		polorizer.wb.reserve(&wire, 1)
+
+		require.Nil(t, polorizer.Polorize([]uint64{1, 2}))
+		assert.False(t, polorizer.wb.filled())
+
+		// The output of a Polorizable value that is recorded for another type is not used
+		polorizer = NewPolorizer()
+		polorizer.plan = &layout{inners: []measured[*Polorizer]{{reflect.TypeOf(BadCustomObject{}), nil}}}
+
+		require.Nil(t, polorizer.Polorize(CustomEncodeObject{A: "foo"}))
+		assert.True(t, polorizer.plan.failed)
+	})
+}
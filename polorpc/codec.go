@@ -0,0 +1,200 @@
+// Package polorpc implements the net/rpc ClientCodec and ServerCodec interfaces with POLO.
+//
+// Request and response headers and bodies are each encoded as a single POLO frame with
+// polo.Encoder, over any io.ReadWriteCloser such as a net.Conn. EncodingOptions given to
+// the codecs apply to the bodies, while headers are always encoded with the defaults.
+package polorpc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/rpc"
+
+	"github.com/sarvalabs/go-polo"
+)
+
+// requestHeader is the wire form of an rpc.Request
+type requestHeader struct {
+	ServiceMethod string
+	Seq           uint64
+}
+
+// responseHeader is the wire form of an rpc.Response
+type responseHeader struct {
+	ServiceMethod string
+	Seq           uint64
+	Error         string
+}
+
+// codec is the shared implementation of the client and server codecs
+type codec struct {
+	conn    io.ReadWriteCloser
+	buffer  *bufio.Writer
+	encoder *polo.Encoder
+	decoder *polo.Decoder
+}
+
+func newCodec(conn io.ReadWriteCloser, options ...polo.EncodingOptions) codec {
+	buffer := bufio.NewWriter(conn)
+
+	return codec{
+		conn:    conn,
+		buffer:  buffer,
+		encoder: polo.NewEncoder(buffer, options...),
+		decoder: polo.NewDecoder(conn, options...),
+	}
+}
+
+// write encodes a header and a body as frames and flushes them to the connection.
+// Nothing is written to the connection if either of them cannot be encoded.
+func (c codec) write(header, body any) error {
+	wire, err := polo.Polorize(header)
+	if err != nil {
+		return err
+	}
+
+	if err = c.encoder.WriteFrame(wire); err != nil {
+		return err
+	}
+
+	if err = c.encoder.Encode(body); err != nil {
+		// Discard the buffered header
+		c.buffer.Reset(c.conn)
+
+		return err
+	}
+
+	return c.buffer.Flush()
+}
+
+// readHeader decodes the next frame into header
+func (c codec) readHeader(header any) error {
+	wire, err := c.decoder.ReadFrame()
+	if err != nil {
+		return err
+	}
+
+	return polo.Depolorize(header, wire)
+}
+
+// readBody decodes the next frame into body, or discards it if body is nil
+func (c codec) readBody(body any) error {
+	if body == nil {
+		_, err := c.decoder.ReadFrame()
+		return err
+	}
+
+	return c.decoder.Decode(body)
+}
+
+// ClientCodec is an rpc.ClientCodec that encodes requests and decodes responses with POLO
+type ClientCodec struct {
+	codec
+}
+
+// NewClientCodec returns a new ClientCodec over the given connection.
+// Accepts EncodingOptions to modify the encoding and decoding behaviour of bodies.
+func NewClientCodec(conn io.ReadWriteCloser, options ...polo.EncodingOptions) *ClientCodec {
+	return &ClientCodec{newCodec(conn, options...)}
+}
+
+// NewClient returns a new rpc.Client that uses a ClientCodec over the given connection.
+func NewClient(conn io.ReadWriteCloser, options ...polo.EncodingOptions) *rpc.Client {
+	return rpc.NewClientWithCodec(NewClientCodec(conn, options...))
+}
+
+// WriteRequest writes the header and the arguments of a request.
+// Implements the rpc.ClientCodec interface for ClientCodec.
+func (client *ClientCodec) WriteRequest(request *rpc.Request, args any) error {
+	return client.write(requestHeader{request.ServiceMethod, request.Seq}, args)
+}
+
+// ReadResponseHeader reads the header of a response.
+// Implements the rpc.ClientCodec interface for ClientCodec.
+func (client *ClientCodec) ReadResponseHeader(response *rpc.Response) error {
+	var header responseHeader
+	if err := client.readHeader(&header); err != nil {
+		return err
+	}
+
+	response.ServiceMethod, response.Seq, response.Error = header.ServiceMethod, header.Seq, header.Error
+
+	return nil
+}
+
+// ReadResponseBody reads the body of a response into reply, or discards it if reply is nil.
+// Implements the rpc.ClientCodec interface for ClientCodec.
+func (client *ClientCodec) ReadResponseBody(reply any) error {
+	return client.readBody(reply)
+}
+
+// Close closes the connection.
+// Implements the rpc.ClientCodec interface for ClientCodec.
+func (client *ClientCodec) Close() error {
+	return client.conn.Close()
+}
+
+// ServerCodec is an rpc.ServerCodec that decodes requests and encodes responses with POLO
+type ServerCodec struct {
+	codec
+}
+
+// NewServerCodec returns a new ServerCodec over the given connection.
+// Accepts EncodingOptions to modify the encoding and decoding behaviour of bodies.
+func NewServerCodec(conn io.ReadWriteCloser, options ...polo.EncodingOptions) *ServerCodec {
+	return &ServerCodec{newCodec(conn, options...)}
+}
+
+// ServeConn runs the given rpc.Server on a single connection with a ServerCodec.
+// It blocks until the client hangs up.
+func ServeConn(server *rpc.Server, conn io.ReadWriteCloser, options ...polo.EncodingOptions) {
+	server.ServeCodec(NewServerCodec(conn, options...))
+}
+
+// ReadRequestHeader reads the header of a request.
+// Implements the rpc.ServerCodec interface for ServerCodec.
+func (server *ServerCodec) ReadRequestHeader(request *rpc.Request) error {
+	var header requestHeader
+	if err := server.readHeader(&header); err != nil {
+		return err
+	}
+
+	request.ServiceMethod, request.Seq = header.ServiceMethod, header.Seq
+
+	return nil
+}
+
+// ReadRequestBody reads the arguments of a request into args, or discards them if args is nil.
+// Implements the rpc.ServerCodec interface for ServerCodec.
+func (server *ServerCodec) ReadRequestBody(args any) error {
+	return server.readBody(args)
+}
+
+// WriteResponse writes the header and the reply of a response. If the reply cannot be encoded, an error
+// response is written instead so that the call of the client does not block. The connection is closed if
+// the error response cannot be written either. Implements the rpc.ServerCodec interface for ServerCodec.
+func (server *ServerCodec) WriteResponse(response *rpc.Response, reply any) error {
+	header := responseHeader{response.ServiceMethod, response.Seq, response.Error}
+
+	err := server.write(header, reply)
+	if err == nil {
+		return nil
+	}
+
+	// Respond with the error and an empty body, which is discarded by the client
+	header.Error = fmt.Sprintf("polorpc: could not encode reply: %v", err)
+	if werr := server.write(header, struct{}{}); werr != nil {
+		_ = server.Close()
+
+		return werr
+	}
+
+	return err
+}
+
+// Close closes the connection.
+// Implements the rpc.ServerCodec interface for ServerCodec.
+func (server *ServerCodec) Close() error {
+	return server.conn.Close()
+}
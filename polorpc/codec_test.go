@@ -0,0 +1,175 @@
+package polorpc
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/rpc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sarvalabs/go-polo"
+)
+
+type Args struct {
+	A, B int64
+	Tags []string
+}
+
+type Quotient struct {
+	Quo, Rem int64
+}
+
+type Arith struct{}
+
+func (Arith) Multiply(args *Args, reply *int64) error {
+	*reply = args.A * args.B
+	return nil
+}
+
+func (Arith) Divide(args *Args, quotient *Quotient) error {
+	if args.B == 0 {
+		return errors.New("divide by zero")
+	}
+
+	quotient.Quo, quotient.Rem = args.A/args.B, args.A%args.B
+
+	return nil
+}
+
+func (Arith) Echo(args *Args, reply *Args) error {
+	*reply = *args
+	return nil
+}
+
+// Stream is a reply that cannot be encoded
+type Stream struct {
+	Values chan int64
+}
+
+func (Arith) Stream(args *Args, reply *Stream) error {
+	reply.Values = make(chan int64)
+	return nil
+}
+
+// newPipe returns an rpc.Client connected to a server with the Arith service over a net.Pipe
+func newPipe(t *testing.T, options ...polo.EncodingOptions) *rpc.Client {
+	t.Helper()
+
+	server := rpc.NewServer()
+	require.NoError(t, server.Register(Arith{}))
+
+	clientConn, serverConn := net.Pipe()
+
+	go ServeConn(server, serverConn, options...)
+
+	client := NewClient(clientConn, options...)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func TestCodec(t *testing.T) {
+	client := newPipe(t)
+
+	var product int64
+	require.NoError(t, client.Call("Arith.Multiply", &Args{A: 7, B: 8}, &product))
+	assert.Equal(t, int64(56), product)
+
+	var quotient Quotient
+	require.NoError(t, client.Call("Arith.Divide", &Args{A: 17, B: 5}, &quotient))
+	assert.Equal(t, Quotient{3, 2}, quotient)
+
+	// Errors from the service
+	err := client.Call("Arith.Divide", &Args{A: 1}, &quotient)
+	assert.EqualError(t, err, "divide by zero")
+
+	// Unknown methods (the request body is discarded by the server)
+	err = client.Call("Arith.Subtract", &Args{A: 1}, &quotient)
+	assert.EqualError(t, err, "rpc: can't find method Arith.Subtract")
+
+	// The connection must remain usable after errors
+	var echo Args
+	require.NoError(t, client.Call("Arith.Echo", &Args{A: 1, B: -2, Tags: []string{"x", "y"}}, &echo))
+	assert.Equal(t, Args{A: 1, B: -2, Tags: []string{"x", "y"}}, echo)
+}
+
+func TestCodec_EncodeErrors(t *testing.T) {
+	client := newPipe(t)
+
+	// Replies that cannot be encoded are responded to with an error
+	done := make(chan error, 1)
+	go func() { done <- client.Call("Arith.Stream", &Args{}, new(Stream)) }()
+
+	select {
+	case err := <-done:
+		assert.EqualError(t, err, "polorpc: could not encode reply: "+
+			"incompatible value error: unsupported type: chan int64 [chan]")
+	case <-time.After(5 * time.Second):
+		t.Fatal("call did not return for a reply that cannot be encoded")
+	}
+
+	// Arguments that cannot be encoded are not sent
+	err := client.Call("Arith.Echo", make(chan int64), new(Args))
+	assert.EqualError(t, err, "incompatible value error: unsupported type: chan int64 [chan]")
+
+	// The connection must remain usable after errors
+	var product int64
+	require.NoError(t, client.Call("Arith.Multiply", &Args{A: 7, B: 8}, &product))
+	assert.Equal(t, int64(56), product)
+}
+
+func TestCodec_Concurrent(t *testing.T) {
+	client := newPipe(t)
+
+	calls := make([]*rpc.Call, 50)
+	for i := range calls {
+		calls[i] = client.Go("Arith.Multiply", &Args{A: int64(i), B: 3}, new(int64), nil)
+	}
+
+	for i, call := range calls {
+		<-call.Done
+		require.NoError(t, call.Error)
+		assert.Equal(t, int64(i*3), *call.Reply.(*int64))
+	}
+}
+
+func TestCodec_Options(t *testing.T) {
+	client := newPipe(t, polo.DocStructs())
+
+	var quotient Quotient
+	require.NoError(t, client.Call("Arith.Divide", &Args{A: 9, B: 2}, &quotient))
+	assert.Equal(t, Quotient{4, 1}, quotient)
+}
+
+func TestCodec_Wire(t *testing.T) {
+	var buffer bytes.Buffer
+
+	codec := NewClientCodec(nopCloser{&buffer})
+	require.NoError(t, codec.WriteRequest(&rpc.Request{ServiceMethod: "Arith.Multiply", Seq: 4}, &Args{A: 1, B: 2}))
+
+	// The request is a header frame followed by a body frame
+	decoder := polo.NewDecoder(&buffer)
+
+	var header requestHeader
+	require.NoError(t, decoder.Decode(&header))
+	assert.Equal(t, requestHeader{"Arith.Multiply", 4}, header)
+
+	var args Args
+	require.NoError(t, decoder.Decode(&args))
+	assert.Equal(t, Args{A: 1, B: 2}, args)
+
+	// Nothing is written if the body cannot be encoded
+	buffer.Reset()
+	require.Error(t, codec.WriteRequest(&rpc.Request{ServiceMethod: "Arith.Multiply", Seq: 5}, make(chan int)))
+	assert.Zero(t, buffer.Len())
+}
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,109 @@
+package polotest
+
+import (
+	"math/big"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+
+	"github.com/sarvalabs/go-polo"
+)
+
+// NewFuzzer returns a new gofuzz Fuzzer that generates objects which can be encoded with POLO.
+// Along with the defaults of gofuzz, it generates valid wires for polo.Any and polo.Raw, documents
+// with valid values for polo.Document and random values for big.Int (including negative values).
+// The Fuzzer can be configured further (such as with NilChance or NumElements) before it is used.
+func NewFuzzer() *fuzz.Fuzzer {
+	return fuzz.New().Funcs(FuzzAny, FuzzRaw, FuzzDocument, FuzzBigInt)
+}
+
+// FuzzRoundTrip generates n objects of type T with the given Fuzzer and calls AssertRoundTrip for each of them.
+// NewFuzzer is used if the Fuzzer is nil. Accepts EncodingOptions that are used for both encoding and decoding.
+func FuzzRoundTrip[T any](t testing.TB, f *fuzz.Fuzzer, n int, options ...polo.EncodingOptions) {
+	t.Helper()
+
+	if f == nil {
+		f = NewFuzzer()
+	}
+
+	for i := 0; i < n; i++ {
+		object := new(T)
+		f.Fuzz(object)
+
+		AssertRoundTrip(t, *object, options...)
+	}
+}
+
+// FuzzAny is a gofuzz function that generates a valid wire with up to two elements for polo.Any.
+func FuzzAny(val *polo.Any, c fuzz.Continue) {
+	polorizer := polo.NewPolorizer()
+
+	for i := 0; i <= c.Intn(2); i++ {
+		switch c.Intn(8) {
+		case 0:
+			polorizer.PolorizeNull()
+		case 1:
+			polorizer.PolorizeBool(c.RandBool())
+		case 2:
+			polorizer.PolorizeBytes([]byte(c.RandString()))
+		case 3:
+			polorizer.PolorizeString(c.RandString())
+		case 4:
+			polorizer.PolorizeUint(c.Uint64())
+		case 5:
+			polorizer.PolorizeInt(c.Int63())
+		case 6:
+			polorizer.PolorizeFloat64(c.Float64())
+		case 7:
+			polorizer.PolorizeFloat32(c.Float32())
+		}
+	}
+
+	*val = polorizer.Bytes()
+}
+
+// FuzzRaw is a gofuzz function that generates a valid wire for polo.Raw, as done by FuzzAny.
+// Null wires are generated as the explicit null wire, because an empty polo.Raw is not a valid wire.
+func FuzzRaw(val *polo.Raw, c fuzz.Continue) {
+	var wire polo.Any
+
+	FuzzAny(&wire, c)
+
+	if len(wire) == 0 {
+		wire = polo.Any{0}
+	}
+
+	*val = polo.Raw(wire)
+}
+
+// FuzzDocument is a gofuzz function that generates a polo.Document whose values are valid wires.
+func FuzzDocument(val *polo.Document, c fuzz.Continue) {
+	doc := make(polo.Document)
+
+	for i := c.Intn(8); i > 0; i-- {
+		var raw polo.Raw
+
+		FuzzRaw(&raw, c)
+		doc.SetRaw(c.RandString(), raw)
+	}
+
+	*val = doc
+}
+
+// FuzzBigInt is a gofuzz function that generates a big.Int of up to 256 bits.
+// Zero values are generated as the zero big.Int, which is the form they are decoded into.
+func FuzzBigInt(val *big.Int, c fuzz.Continue) {
+	bytes := make([]byte, c.Intn(33))
+	for i := range bytes {
+		bytes[i] = byte(c.Uint32())
+	}
+
+	val.SetBytes(bytes)
+
+	switch {
+	case val.Sign() == 0:
+		*val = big.Int{}
+	case c.RandBool():
+		val.Neg(val)
+	}
+}
@@ -0,0 +1,64 @@
+package polotest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sarvalabs/go-polo"
+)
+
+type FuzzObject struct {
+	A polo.Any
+	B polo.Raw
+	C polo.Document
+	D *big.Int
+	E map[string][]int16
+	F [4]float32
+}
+
+func TestFuzzRoundTrip(t *testing.T) {
+	FuzzRoundTrip[Fruit](t, nil, 1000)
+	FuzzRoundTrip[FuzzObject](t, NewFuzzer().NilChance(0).NumElements(0, 4), 1000)
+	FuzzRoundTrip[map[string]polo.Document](t, NewFuzzer().NilChance(0), 100, polo.DocStringMaps())
+}
+
+func TestFuzzers(t *testing.T) {
+	f := NewFuzzer()
+
+	for i := 0; i < 1000; i++ {
+		var object FuzzObject
+
+		f.Fuzz(&object)
+
+		// Generated wires must be decodable
+		_, err := polo.Polorize(object.A)
+		require.NoError(t, err)
+
+		err = polo.Depolorize(new(polo.Any), object.B)
+		require.NoError(t, err)
+
+		for key := range object.C {
+			assert.NotEmpty(t, object.C.GetRaw(key))
+		}
+
+		if object.D != nil {
+			assert.LessOrEqual(t, object.D.BitLen(), 256)
+		}
+	}
+
+	// Big integers are generated with both signs
+	var negative, positive bool
+
+	for i := 0; i < 100; i++ {
+		var x big.Int
+
+		f.Fuzz(&x)
+
+		negative, positive = negative || x.Sign() < 0, positive || x.Sign() > 0
+	}
+
+	assert.True(t, negative && positive)
+}
@@ -0,0 +1,194 @@
+// Package polotest implements helpers for testing types that are encoded with POLO.
+//
+// AssertRoundTrip checks that an object survives encoding and decoding, AssertDeterministic
+// checks that an object always has the same wire (regardless of map iteration order) and
+// AssertGolden checks the wire of an object against a stored hex file. FuzzRoundTrip runs
+// AssertRoundTrip against objects generated with gofuzz, see NewFuzzer for the generators.
+package polotest
+
+import (
+	"encoding/hex"
+	"flag"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sarvalabs/go-polo"
+)
+
+// update is the flag to regenerate the golden files of AssertGolden instead of comparing against them.
+// It is set by running the tests with 'go test ./... -args -polotest.update'.
+var update = flag.Bool("polotest.update", false, "update the golden files of polotest.AssertGolden")
+
+// AssertRoundTrip serializes an object into its POLO byte form, deserializes it into a new object of the
+// same type and requires that the objects are equal. The new object is serialized again and its wire must
+// be equal to the original wire. Accepts EncodingOptions that are used for both encoding and decoding.
+// Returns the wire of the object.
+func AssertRoundTrip[T any](t testing.TB, object T, options ...polo.EncodingOptions) []byte {
+	t.Helper()
+
+	wire, err := polo.Polorize(object, options...)
+	require.NoError(t, err, "could not polorize object: %v", object)
+
+	decoded := new(T)
+	err = polo.Depolorize(decoded, wire, options...)
+	require.NoError(t, err, "could not depolorize object: %v", object)
+	require.Equal(t, object, *decoded, "object mismatch after round trip")
+
+	rewire, err := polo.Polorize(*decoded, options...)
+	require.NoError(t, err, "could not polorize decoded object: %v", *decoded)
+	require.Equal(t, wire, rewire, "wire mismatch after round trip")
+
+	return wire
+}
+
+// AssertDeterministic serializes an object into its POLO byte form n times (at least once) and requires
+// that the wire is always the same. Each time, the object is also serialized after being deep copied
+// with the entries of all its maps inserted in a random order, which changes their iteration order.
+// Accepts EncodingOptions to modify the encoding behaviour. Returns the wire of the object.
+func AssertDeterministic[T any](t testing.TB, object T, n int, options ...polo.EncodingOptions) []byte {
+	t.Helper()
+
+	wire, err := polo.Polorize(object, options...)
+	require.NoError(t, err, "could not polorize object: %v", object)
+
+	for i := 0; i < n; i++ {
+		rewire, err := polo.Polorize(object, options...)
+		require.NoError(t, err, "could not polorize object: %v", object)
+		require.Equal(t, wire, rewire, "wire mismatch on encoding %v", i)
+
+		copied := shuffledCopy(reflect.ValueOf(&object).Elem()).Interface()
+
+		rewire, err = polo.Polorize(copied, options...)
+		require.NoError(t, err, "could not polorize copied object: %v", copied)
+		require.Equal(t, wire, rewire, "wire mismatch on encoding %v with shuffled maps", i)
+	}
+
+	return wire
+}
+
+// shuffledCopy returns a deep copy of some value, with the entries of all
+// maps inserted in a random order. Unexported struct fields are not copied deeply.
+func shuffledCopy(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+
+		keys := value.MapKeys()
+		rand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+		copied := reflect.MakeMapWithSize(value.Type(), len(keys))
+		for _, key := range keys {
+			copied.SetMapIndex(shuffledCopy(key), shuffledCopy(value.MapIndex(key)))
+		}
+
+		return copied
+
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(shuffledCopy(value.Index(i)))
+		}
+
+		return copied
+
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(shuffledCopy(value.Index(i)))
+		}
+
+		return copied
+
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.New(value.Type().Elem())
+		copied.Elem().Set(shuffledCopy(value.Elem()))
+
+		return copied
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(shuffledCopy(value.Elem()))
+
+		return copied
+
+	case reflect.Struct:
+		// Copy the whole struct and then replace the exported fields with their copies
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+
+		for i := 0; i < value.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(shuffledCopy(value.Field(i)))
+			}
+		}
+
+		return copied
+
+	default:
+		return value
+	}
+}
+
+// AssertGolden serializes an object into its POLO byte form and requires that the wire is equal to the wire
+// stored as hex in the golden file at the given path. The golden wire must also decode into an object that is
+// equal to the given object. Whitespace in the golden file is ignored. If the tests are run with the flag
+// '-polotest.update', the golden file is (re)written with the wire instead. Accepts EncodingOptions that are
+// used for both encoding and decoding. Returns the wire of the object.
+func AssertGolden[T any](t testing.TB, object T, path string, options ...polo.EncodingOptions) []byte {
+	t.Helper()
+
+	wire, err := polo.Polorize(object, options...)
+	require.NoError(t, err, "could not polorize object: %v", object)
+
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(hex.EncodeToString(wire)+"\n"), 0o644)) //nolint:gosec
+
+		return wire
+	}
+
+	golden, err := readGolden(path)
+	require.NoError(t, err, "could not read golden file (run with -polotest.update to create it)")
+	require.Equal(t, golden, wire, "wire mismatch with golden file %v", path)
+
+	decoded := new(T)
+	err = polo.Depolorize(decoded, golden, options...)
+	require.NoError(t, err, "could not depolorize golden wire")
+	require.Equal(t, object, *decoded, "object mismatch with golden file %v", path)
+
+	return wire
+}
+
+// readGolden reads the wire stored as hex in a golden file, ignoring any whitespace
+func readGolden(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove all whitespace from the hex and an optional 0x prefix
+	encoded := strings.Join(strings.Fields(string(data)), "")
+	encoded = strings.TrimPrefix(encoded, "0x")
+
+	return hex.DecodeString(encoded)
+}
@@ -0,0 +1,125 @@
+package polotest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sarvalabs/go-polo"
+)
+
+type Fruit struct {
+	Name   string
+	Cost   int
+	Alias  []string
+	Prices map[string]uint64
+	Stock  map[string]map[int32]bool
+}
+
+func testFruit() Fruit {
+	return Fruit{
+		Name:   "orange",
+		Cost:   300,
+		Alias:  []string{"tangerine", "mandarin"},
+		Prices: map[string]uint64{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8},
+		Stock:  map[string]map[int32]bool{"x": {1: true, 2: false, 3: true}, "y": {-1: true}, "z": nil},
+	}
+}
+
+// recorder is a testing.TB that records failures instead of failing the test
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+// failure is the panic value used by recorder to stop a failing assertion
+type failure struct{}
+
+func (r *recorder) Errorf(string, ...any) { r.failed = true }
+func (r *recorder) FailNow()              { panic(failure{}) }
+
+// fails returns whether the given assertion fails for a recorder
+func fails(t *testing.T, assertion func(t testing.TB)) (failed bool) {
+	t.Helper()
+
+	r := &recorder{TB: t}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if _, ok := recovered.(failure); !ok {
+				panic(recovered)
+			}
+
+			failed = true
+		}
+	}()
+
+	assertion(r)
+
+	return r.failed
+}
+
+type Mismatched struct {
+	A string
+	b string
+}
+
+func TestAssertRoundTrip(t *testing.T) {
+	wire := AssertRoundTrip(t, testFruit())
+
+	expected, err := polo.Polorize(testFruit())
+	require.NoError(t, err)
+	assert.Equal(t, expected, wire)
+
+	AssertRoundTrip(t, map[string]string{"a": "b"})
+	AssertRoundTrip(t, testFruit(), polo.DocStructs())
+
+	// Unexported fields are not encoded and do not survive the round trip
+	assert.True(t, fails(t, func(t testing.TB) { AssertRoundTrip(t, Mismatched{"a", "b"}) }))
+	assert.False(t, fails(t, func(t testing.TB) { AssertRoundTrip(t, Mismatched{A: "a"}) }))
+
+	// Objects that cannot be encoded fail
+	assert.True(t, fails(t, func(t testing.TB) { AssertRoundTrip(t, make(chan int)) }))
+}
+
+func TestAssertDeterministic(t *testing.T) {
+	wire := AssertDeterministic(t, testFruit(), 100)
+	assert.Equal(t, AssertRoundTrip(t, testFruit()), wire)
+
+	AssertDeterministic(t, &Fruit{Prices: map[string]uint64{"a": 1}}, 10)
+	AssertDeterministic(t, []map[float64]string{{1.5: "a", -2: "b"}, nil}, 10)
+	AssertDeterministic(t, testFruit(), 10, polo.DocStructs())
+}
+
+func TestShuffledCopy(t *testing.T) {
+	fruit := testFruit()
+	object := []*Fruit{&fruit, nil}
+
+	copied := shuffledCopy(reflect.ValueOf(&object).Elem()).Interface().([]*Fruit)
+	assert.Equal(t, object, copied)
+
+	// The copy must not share any maps, slices or pointers with the original
+	copied[0].Prices["a"] = 100
+	copied[0].Stock["x"][1] = false
+	copied[0].Alias[0] = "clementine"
+
+	assert.Equal(t, testFruit(), fruit)
+}
+
+func TestAssertGolden(t *testing.T) {
+	AssertGolden(t, testFruit(), "testdata/fruit.hex")
+	AssertGolden(t, testFruit(), "testdata/fruit-doc.hex", polo.DocStructs())
+
+	// Golden files can have whitespace and a 0x prefix
+	path := filepath.Join(t.TempDir(), "spaced.hex")
+	require.NoError(t, os.WriteFile(path, []byte("0x06\n01 01\n"), 0o600))
+	assert.False(t, fails(t, func(t testing.TB) { AssertGolden(t, []uint8{1, 1}, path) }))
+
+	// Mismatched and missing golden files fail
+	assert.True(t, fails(t, func(t testing.TB) { AssertGolden(t, []uint8{1, 2}, path) }))
+	assert.True(t, fails(t, func(t testing.TB) { AssertGolden(t, 5, filepath.Join(t.TempDir(), "missing.hex")) }))
+}
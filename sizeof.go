@@ -0,0 +1,661 @@
+package polo
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SizeOf returns the number of bytes in the POLO byte form of an object, i.e, the length of the
+// wire returned by Polorize for the same object and EncodingOptions. The size is computed without
+// encoding the object, apart from objects that implement Polorizable, which must be encoded to be measured.
+// Returns an error if object is an unsupported type such as functions or channels, or if the Compression
+// option is used, as the size of a compressed wire cannot be known without compressing it.
+func SizeOf(object any, options ...EncodingOptions) (int, error) {
+	// Generate a default wire config
+	config := defaultWireConfig()
+	// Apply any given options to the config
+	config.apply(options...)
+
+	if config.compressor != nil {
+		return 0, errors.New("cannot compute size of compressed wire")
+	}
+
+	sz := sizer{cfg: *config}
+	if err := sz.sizeValue(reflect.ValueOf(object)); err != nil {
+		return 0, err
+	}
+
+	return sz.sb.size(), nil
+}
+
+// sizebuffer is a counterpart of writebuffer that tracks the size of
+// its head and body for the elements written into it without storing them.
+type sizebuffer struct {
+	head, body      int
+	offset, counter uint64
+}
+
+// write adds an element of size n with the given WireType to the sizebuffer.
+// The head is incremented by the size of the varint tag for the element.
+func (sb *sizebuffer) write(w WireType, n int) {
+	sb.head += sizeVarint((sb.offset << 4) | uint64(w))
+	sb.body += n
+
+	// Increment the offset by the number of bytes written to the body
+	sb.offset += uint64(n)
+	// Increment counter to represent the number of written elements
+	sb.counter++
+}
+
+// writeLoad adds the contents of another sizebuffer as a load element with the given WireType to the sizebuffer.
+func (sb *sizebuffer) writeLoad(w WireType, load sizebuffer) {
+	sb.write(w, load.loadSize())
+}
+
+// size returns the number of bytes in the contents of the sizebuffer.
+func (sb sizebuffer) size() int {
+	return sb.head + sb.body
+}
+
+// loadSize returns the number of bytes in the contents of the sizebuffer as a load.
+func (sb sizebuffer) loadSize() int {
+	return sizeVarint((uint64(sb.head)<<4)|uint64(WireLoad)) + sb.size()
+}
+
+// sizer is the counterpart of Polorizer that measures objects instead of encoding them.
+// Every method of the sizer mirrors the encoding behaviour of a Polorizer method.
+// If the sizer has a layout, its measurements are recorded into it (see layout).
+type sizer struct {
+	sb   sizebuffer
+	cfg  wireConfig
+	plan *layout
+}
+
+// layout is a record of the measurements made by a sizer for an object, which allows a Polorizer to
+// encode the object in place. Each compound value is written directly into the output after a head of
+// the recorded size, instead of being encoded into a nested buffer that is then copied into its parent.
+//
+// The outputs of Polorizable values and the documents of doc-encoded values must be encoded to be
+// measured, so they are also recorded and reused by the Polorizer instead of being encoded again,
+// as are the sorted entries of maps. Records are consumed in the order they were made, which is the
+// order in which values are encoded.
+type layout struct {
+	heads  []int
+	inners []measured[*Polorizer]
+	docs   []measured[Document]
+
+	// maps are the pointers of the recorded maps and entries are
+	// their keys in sorted order, with each key followed by its value
+	maps    []measured[uintptr]
+	entries []reflect.Value
+
+	// consumed is the number of records of each kind that have been consumed
+	consumed struct{ heads, inners, docs, maps, entries int }
+	// failed is set if a record is consumed for a value that it does not match
+	failed bool
+
+	// wire is the output that the Polorizer encodes in place into
+	wire []byte
+}
+
+// measured is a record made by a sizer for a value of some type.
+type measured[T any] struct {
+	typ reflect.Type
+	val T
+}
+
+// layoutPool is a pool of layout objects that are reused by the encoding functions.
+var layoutPool = sync.Pool{
+	New: func() any { return new(layout) },
+}
+
+// reset clears the records of the layout while retaining their capacity.
+func (plan *layout) reset() {
+	clear(plan.inners)
+	clear(plan.docs)
+	clear(plan.entries)
+
+	plan.heads, plan.inners, plan.docs = plan.heads[:0], plan.inners[:0], plan.docs[:0]
+	plan.maps, plan.entries = plan.maps[:0], plan.entries[:0]
+
+	plan.consumed = struct{ heads, inners, docs, maps, entries int }{}
+	plan.failed, plan.wire = false, nil
+}
+
+// openHead records a head whose size is not yet known and returns its position for closeHead.
+// Heads are recorded when their compound value is opened, so that nested values are recorded after it.
+func (plan *layout) openHead() int {
+	if plan == nil {
+		return -1
+	}
+
+	plan.heads = append(plan.heads, 0)
+
+	return len(plan.heads) - 1
+}
+
+// closeHead sets the size of the head recorded at some position by openHead.
+func (plan *layout) closeHead(pos, head int) {
+	if plan != nil {
+		plan.heads[pos] = head
+	}
+}
+
+// nextHead consumes the next recorded head size. Returns false if there are no more heads.
+func (plan *layout) nextHead() (int, bool) {
+	if plan == nil || plan.consumed.heads == len(plan.heads) {
+		return 0, false
+	}
+
+	plan.consumed.heads++
+
+	return plan.heads[plan.consumed.heads-1], true
+}
+
+// nextInner consumes the next recorded output of a Polorizable value of the given type.
+// Returns false if there is no such record.
+func (plan *layout) nextInner(typ reflect.Type) (*Polorizer, bool) {
+	if plan == nil {
+		return nil, false
+	}
+
+	return consumeMeasured(plan, plan.inners, &plan.consumed.inners, typ)
+}
+
+// nextDoc consumes the next recorded document of a doc-encoded value of the given type.
+// Returns false if there is no such record.
+func (plan *layout) nextDoc(typ reflect.Type) (Document, bool) {
+	if plan == nil {
+		return nil, false
+	}
+
+	return consumeMeasured(plan, plan.docs, &plan.consumed.docs, typ)
+}
+
+// recordEntries records the entries of a map with the given sorted keys and returns them.
+// Returns nil if the layout is nil.
+func (plan *layout) recordEntries(mapping reflect.Value, keys []reflect.Value) []reflect.Value {
+	if plan == nil {
+		return nil
+	}
+
+	plan.maps = append(plan.maps, measured[uintptr]{mapping.Type(), mapping.Pointer()})
+
+	start := len(plan.entries)
+	for _, k := range keys {
+		plan.entries = append(plan.entries, k, mapping.MapIndex(k))
+	}
+
+	return plan.entries[start:]
+}
+
+// nextEntries consumes the next recorded entries of a map, which must be the given map.
+// Returns false if there is no such record.
+func (plan *layout) nextEntries(mapping reflect.Value) ([]reflect.Value, bool) {
+	if plan == nil {
+		return nil, false
+	}
+
+	pointer, ok := consumeMeasured(plan, plan.maps, &plan.consumed.maps, mapping.Type())
+	if !ok || pointer != mapping.Pointer() {
+		plan.failed = true
+		return nil, false //nolint:nlreturn
+	}
+
+	entries := plan.entries[plan.consumed.entries : plan.consumed.entries+2*mapping.Len()]
+	plan.consumed.entries += len(entries)
+
+	return entries, true
+}
+
+// consumeMeasured consumes the next record from some records of a layout if it is for the given type.
+// The layout is marked as failed if the next record is missing or of a different type.
+func consumeMeasured[T any](plan *layout, records []measured[T], next *int, typ reflect.Type) (T, bool) {
+	if *next == len(records) || records[*next].typ != typ {
+		plan.failed = true
+		return *new(T), false //nolint:nlreturn
+	}
+
+	*next++
+
+	return records[*next-1].val, true
+}
+
+// docEntry is the key and encoded size of a value in a document
+type docEntry struct {
+	key  string
+	size int
+}
+
+// sizeDocument adds a document with the given entries to the sizer.
+// The entries are sorted by their key, mirroring Polorizer.PolorizeDocument.
+func (sz *sizer) sizeDocument(entries []docEntry) {
+	slices.SortFunc(entries, func(a, b docEntry) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	var document sizebuffer
+	for _, entry := range entries {
+		document.write(WireWord, len(entry.key))
+		document.write(WireRaw, entry.size)
+	}
+
+	sz.plan.closeHead(sz.plan.openHead(), document.head)
+	sz.sb.writeLoad(WireDoc, document)
+}
+
+// sizeDocumentValue adds a Document to the sizer, mirroring Polorizer.PolorizeDocument.
+func (sz *sizer) sizeDocumentValue(document Document) {
+	entries := make([]docEntry, 0, len(document))
+	for key, raw := range document {
+		entries = append(entries, docEntry{key, sizeRaw(raw)})
+	}
+
+	sz.sizeDocument(entries)
+}
+
+// sizeEncodedDoc adds the document of a doc-encoded value of the given type to the sizer and records it
+// into the layout of the sizer. It is used instead of measuring each value of the document with sizeDocValue
+// when the sizer has a layout, so that the Polorizer can reuse the document without encoding it again.
+func (sz *sizer) sizeEncodedDoc(typ reflect.Type, document Document) {
+	sz.plan.docs = append(sz.plan.docs, measured[Document]{typ, document})
+	sz.sizeDocumentValue(document)
+}
+
+// sizeDocValue returns the size of an object when it is set into a document,
+// i.e, the size of its wire as polorized with the config of the sizer
+func (sz *sizer) sizeDocValue(key string, value reflect.Value) (int, error) {
+	inner := sz.nested()
+	if err := inner.sizeValue(value); err != nil {
+		return 0, fmt.Errorf("could not encode into document: %w",
+			fmt.Errorf("document value could not be encoded for key '%v': %w", key, err))
+	}
+
+	return inner.sb.size(), nil
+}
+
+// sizeBytes adds a bytes value of length n to the sizer, mirroring Polorizer.PolorizeBytes.
+func (sz *sizer) sizeBytes(n int) {
+	if !sz.cfg.packBytes {
+		sz.sb.write(WireWord, n)
+		return
+	}
+
+	// Each byte is encoded as a single byte WirePosInt
+	var pack sizebuffer
+	for i := 0; i < n; i++ {
+		pack.write(WirePosInt, 1)
+	}
+
+	sz.plan.closeHead(sz.plan.openHead(), pack.head)
+	sz.sb.writeLoad(WirePack, pack)
+}
+
+// sizeRaw returns the size of a Raw value, mirroring Polorizer.PolorizeRaw.
+// A nil Raw is encoded as Raw{0}, while an empty Raw is encoded as is.
+func sizeRaw(raw Raw) int {
+	if raw == nil {
+		return 1
+	}
+
+	return len(raw)
+}
+
+// sizeInt adds a signed integer to the sizer, mirroring Polorizer.PolorizeInt.
+func (sz *sizer) sizeInt(value int64) {
+	if value < 0 {
+		sz.sb.write(WireNegInt, sizeInteger(uint64(-value)))
+		return
+	}
+
+	sz.sb.write(WirePosInt, sizeInteger(uint64(value)))
+}
+
+// sizeBigInt adds a big.Int to the sizer, mirroring Polorizer.PolorizeBigInt.
+func (sz *sizer) sizeBigInt(value *big.Int) {
+	wiretype := WirePosInt
+	if value.Sign() == -1 {
+		wiretype = WireNegInt
+	}
+
+	sz.sb.write(wiretype, (value.BitLen()+7)/8)
+}
+
+// sizeAny adds an Any to the sizer, mirroring Polorizer.PolorizeAny.
+func (sz *sizer) sizeAny(value Any) error {
+	if value == nil {
+		sz.sb.write(WireNull, 0)
+		return nil
+	}
+
+	rb, err := newreadbuffer(value)
+	if err != nil {
+		return err
+	}
+
+	sz.sb.write(rb.wire, len(rb.data))
+
+	return nil
+}
+
+// sizePolorizable adds a value that implements Polorizable to the sizer, mirroring Polorizer.polorizePolorizable.
+// The value is encoded with its Polorize method and the resulting Polorizer is measured.
+func (sz *sizer) sizePolorizable(value reflect.Value) error {
+	outputs := value.MethodByName("Polorize").Call([]reflect.Value{})
+	if !outputs[1].IsNil() {
+		return outputs[1].Interface().(error) //nolint:forcetypeassert
+	}
+
+	inner, _ := outputs[0].Interface().(*Polorizer)
+	if sz.plan != nil {
+		sz.plan.inners = append(sz.plan.inners, measured[*Polorizer]{value.Type(), inner})
+	}
+
+	if inner == nil {
+		sz.sb.write(WireNull, 0)
+		return nil
+	}
+
+	switch inner.wb.counter {
+	case 0:
+		sz.sb.write(WireNull, 0)
+	case 1:
+		sz.sb.write(WireType(inner.wb.head[0]&15), len(inner.wb.body))
+	default:
+		sz.sb.write(WirePack, inner.wb.loadSize())
+	}
+
+	return nil
+}
+
+// sizeStructValue adds a struct to the sizer, mirroring Polorizer.polorizeStructValue.
+func (sz *sizer) sizeStructValue(value reflect.Value) error {
+	t := value.Type()
+
+	// Structs encoded as documents
+	if sz.cfg.docStructs {
+		if sz.plan != nil {
+			doc, err := (&Polorizer{cfg: sz.cfg}).polorizeStructIntoDoc(value)
+			if err != nil {
+				return err
+			}
+
+			sz.sizeEncodedDoc(t, doc)
+
+			return nil
+		}
+
+		entries := make([]docEntry, 0, t.NumField())
+
+		for i, tag := range fieldTags(t) {
+			// Skip the field if it is not exported or if it
+			// is manually tagged to be skipped with a '-' tag
+			if tag.skip || tag.unknown {
+				continue
+			}
+
+			size, err := sz.sizeDocValue(tag.name, value.Field(i))
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, docEntry{tag.name, size})
+		}
+
+		// Add the unknown document keys that are not set by a struct field
+		unknown, err := unknownField(t)
+		if err != nil {
+			return err
+		}
+
+		if unknown != -1 {
+			for key, data := range value.Field(unknown).Interface().(Document) { //nolint:forcetypeassert
+				if !slices.ContainsFunc(entries, func(entry docEntry) bool { return entry.key == key }) {
+					entries = append(entries, docEntry{key, sizeRaw(data)})
+				}
+			}
+		}
+
+		sz.sizeDocument(entries)
+
+		return nil
+	}
+
+	head := sz.plan.openHead()
+	structure := sz.nested()
+
+	for i, tag := range fieldTags(t) {
+		// Skip the field if it is not exported or if it
+		// is manually tagged to be skipped with a '-' tag
+		if tag.skip || tag.rest {
+			continue
+		}
+
+		if err := structure.sizeValue(value.Field(i)); err != nil {
+			return err
+		}
+	}
+
+	// Add the trailing elements after all the other fields
+	rest, err := restField(t)
+	if err != nil {
+		return err
+	}
+
+	if rest != -1 {
+		elements := value.Field(rest)
+		for i := 0; i < elements.Len(); i++ {
+			if err = structure.sizeValue(elements.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	sz.plan.closeHead(head, structure.sb.head)
+	sz.sb.writeLoad(WirePack, structure.sb)
+
+	return nil
+}
+
+// sizeMapValue adds a map to the sizer, mirroring Polorizer.polorizeMapValue.
+func (sz *sizer) sizeMapValue(value reflect.Value) error {
+	// String maps encoded as documents
+	if sz.cfg.docStrMaps && value.Type().Key().Kind() == reflect.String {
+		if sz.plan != nil {
+			doc, err := (&Polorizer{cfg: sz.cfg}).polorizeStrMapIntoDoc(value)
+			if err != nil {
+				return err
+			}
+
+			sz.sizeEncodedDoc(value.Type(), doc)
+
+			return nil
+		}
+
+		entries := make([]docEntry, 0, value.Len())
+
+		iter := value.MapRange()
+		for iter.Next() {
+			size, err := sz.sizeDocValue(iter.Key().String(), iter.Value())
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, docEntry{iter.Key().String(), size})
+		}
+
+		sz.sizeDocument(entries)
+
+		return nil
+	}
+
+	// The keys must be sorted, as the size of element tags depends on their offsets
+	keys := value.MapKeys()
+	if err := SortKeys(keys); err != nil {
+		return IncompatibleValueError{fmt.Sprintf("cannot sort map keys of type %v: %v", value.Type().Key(), err)}
+	}
+
+	if err := sortKeyTies(value, keys, sz.cfg); err != nil {
+		return err
+	}
+
+	// Record the sorted entries of the map before any of its nested values are recorded
+	entries := sz.plan.recordEntries(value, keys)
+
+	head := sz.plan.openHead()
+	mapping := sz.nested()
+
+	for i, k := range keys {
+		if err := mapping.sizeValue(k); err != nil {
+			return err
+		}
+
+		// Use the recorded value of the key, if any
+		var v reflect.Value
+		if entries != nil {
+			v = entries[2*i+1]
+		} else {
+			v = value.MapIndex(k)
+		}
+
+		if err := mapping.sizeValue(v); err != nil {
+			return err
+		}
+	}
+
+	sz.plan.closeHead(head, mapping.sb.head)
+	sz.sb.writeLoad(WirePack, mapping.sb)
+
+	return nil
+}
+
+// sizeArrayValue adds an array or slice to the sizer, mirroring Polorizer.polorizeArrayValue.
+func (sz *sizer) sizeArrayValue(value reflect.Value) error {
+	head := sz.plan.openHead()
+	array := sz.nested()
+
+	for i := 0; i < value.Len(); i++ {
+		if err := array.sizeValue(value.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	sz.plan.closeHead(head, array.sb.head)
+	sz.sb.writeLoad(WirePack, array.sb)
+
+	return nil
+}
+
+// nested returns an empty sizer that inherits the configuration and layout of the sizer
+func (sz *sizer) nested() sizer {
+	return sizer{cfg: sz.cfg, plan: sz.plan}
+}
+
+// sizeValue accepts a reflect.Value and adds its encoded size to the sizer, mirroring Polorizer.polorizeValue.
+func (sz *sizer) sizeValue(value reflect.Value) error {
+	// Untyped Nil
+	if value == zeroVal {
+		return IncompatibleValueError{"unsupported type: cannot encode untyped nil"}
+	}
+
+	// Nil Pointer
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		sz.sb.write(WireNull, 0)
+		return nil
+	}
+
+	// Polorizable Type
+	if value.Type().Implements(reflect.TypeOf((*Polorizable)(nil)).Elem()) {
+		return sz.sizePolorizable(value)
+	}
+
+	switch kind := value.Kind(); kind {
+	case reflect.Ptr:
+		return sz.sizeValue(value.Elem())
+
+	case reflect.Bool:
+		if value.Bool() {
+			sz.sb.write(WireTrue, 0)
+		} else {
+			sz.sb.write(WireFalse, 0)
+		}
+
+	case reflect.String:
+		sz.sb.write(WireWord, value.Len())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		sz.sb.write(WirePosInt, sizeInteger(value.Uint()))
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sz.sizeInt(value.Int())
+
+	case reflect.Float32:
+		sz.sb.write(WireFloat, 4)
+
+	case reflect.Float64:
+		sz.sb.write(WireFloat, 8)
+
+	case reflect.Slice:
+		switch {
+		// Nil Slice
+		case value.IsNil():
+			sz.sb.write(WireNull, 0)
+		// Any Bytes
+		case value.Type() == reflect.TypeOf(Any{}):
+			return sz.sizeAny(value.Bytes())
+		// Raw Bytes
+		case value.Type() == reflect.TypeOf(Raw{}):
+			sz.sb.write(WireRaw, sizeRaw(value.Bytes()))
+		// Byte Slice
+		case value.Type().Elem().Kind() == reflect.Uint8:
+			sz.sizeBytes(value.Len())
+		default:
+			return sz.sizeArrayValue(value)
+		}
+
+	case reflect.Array:
+		// Byte Array
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			sz.sizeBytes(value.Len())
+			return nil
+		}
+
+		return sz.sizeArrayValue(value)
+
+	case reflect.Map:
+		// Nil Map
+		if value.IsNil() {
+			sz.sb.write(WireNull, 0)
+			return nil
+		}
+
+		// Document
+		if value.Type() == reflect.TypeOf(Document{}) {
+			sz.sizeDocumentValue(value.Interface().(Document)) //nolint:forcetypeassert
+			return nil
+		}
+
+		return sz.sizeMapValue(value)
+
+	case reflect.Struct:
+		// BigInt
+		if value.Type() == reflect.TypeOf(*big.NewInt(0)) {
+			bignumber, _ := value.Interface().(big.Int)
+			sz.sizeBigInt(&bignumber)
+
+			return nil
+		}
+
+		return sz.sizeStructValue(value)
+
+	default:
+		return UnsupportedTypeError(value.Type())
+	}
+
+	return nil
+}
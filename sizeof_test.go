@@ -0,0 +1,169 @@
+package polo
+
+import (
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSizeOf verifies that the size computed by SizeOf for a given object
+// matches the length of its wire from Polorize with the same options
+func testSizeOf[T any](t *testing.T, x T, options ...EncodingOptions) {
+	t.Helper()
+
+	wire, err := Polorize(x, options...)
+	require.Nil(t, err)
+
+	size, err := SizeOf(x, options...)
+	require.Nil(t, err)
+	require.Equal(t, len(wire), size, "Size Mismatch. Input: %v", x)
+}
+
+func TestSizeOf(t *testing.T) {
+	f := fuzz.New().NumElements(0, 8).NilChance(0.2).Funcs(fuzzAny, fuzzRaw)
+
+	t.Run("IntegerObject", func(t *testing.T) {
+		var x IntegerObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testSizeOf(t, x)
+		}
+	})
+
+	t.Run("SequenceObject", func(t *testing.T) {
+		var x SequenceObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testSizeOf(t, x)
+			testSizeOf(t, x, PackedBytes())
+		}
+	})
+
+	t.Run("MapObject", func(t *testing.T) {
+		var x MapObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testSizeOf(t, x)
+			testSizeOf(t, x, DocStringMaps())
+		}
+	})
+
+	t.Run("NestedObject", func(t *testing.T) {
+		var x NestedObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testSizeOf(t, x)
+			testSizeOf(t, x, DocStructs())
+		}
+	})
+
+	t.Run("AnyObject", func(t *testing.T) {
+		var x AnyObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testSizeOf(t, x)
+		}
+	})
+
+	t.Run("BigObject", func(t *testing.T) {
+		var x BigObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testSizeOf(t, x)
+		}
+	})
+
+	t.Run("Document", func(t *testing.T) {
+		var x Document
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testSizeOf(t, x)
+		}
+	})
+
+	t.Run("CustomEncodeObject", func(t *testing.T) {
+		var x CustomEncodeObject
+
+		for i := 0; i < 1000; i++ {
+			f.Fuzz(&x)
+			testSizeOf(t, x)
+		}
+	})
+
+	t.Run("Raw Values", func(t *testing.T) {
+		type UnknownObject struct {
+			A Raw
+			B string
+			C Document `polo:",unknown"`
+		}
+
+		// Raw values are fuzzed to be nil, empty or valid wires, as a nil
+		// Raw is encoded as Raw{0} while an empty Raw is encoded as is
+		g := fuzz.New().NumElements(0, 8).NilChance(0.2).Funcs(fuzzAny, func(val *Raw, c fuzz.Continue) {
+			switch c.Intn(3) {
+			case 0:
+				*val = nil
+			case 1:
+				*val = Raw{}
+			default:
+				fuzzRaw(val, c)
+			}
+		})
+
+		var (
+			raw     Raw
+			doc     Document
+			unknown UnknownObject
+		)
+
+		for i := 0; i < 1000; i++ {
+			g.Fuzz(&raw)
+			testSizeOf(t, raw)
+
+			g.Fuzz(&doc)
+			testSizeOf(t, doc)
+
+			g.Fuzz(&unknown)
+			testSizeOf(t, unknown, DocStructs())
+		}
+
+		testSizeOf(t, Raw{})
+		testSizeOf(t, Document{"a": Raw{}, "b": nil})
+		testSizeOf(t, UnknownObject{C: Document{"d": nil}}, DocStructs())
+	})
+
+	t.Run("Large Offsets", func(t *testing.T) {
+		testSizeOf(t, []string{string(make([]byte, 1<<16)), "foo", string(make([]byte, 1<<10)), "bar"})
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := SizeOf(make(chan string))
+		assert.EqualError(t, err, "incompatible value error: unsupported type: chan string [chan]")
+
+		_, err = SizeOf(nil)
+		assert.EqualError(t, err, "incompatible value error: unsupported type: cannot encode untyped nil")
+
+		_, err = SizeOf("orange", Compression(Gzip(6)))
+		assert.EqualError(t, err, "cannot compute size of compressed wire")
+	})
+}
+
+func TestPolorizer_Len(t *testing.T) {
+	polorizer := NewPolorizer()
+	assert.Equal(t, len(polorizer.Bytes()), polorizer.Len())
+
+	polorizer.PolorizeString("foo")
+	assert.Equal(t, len(polorizer.Bytes()), polorizer.Len())
+
+	require.Nil(t, polorizer.Polorize(map[string][]uint64{"bar": {1, 2, 300}}))
+	assert.Equal(t, len(polorizer.Bytes()), polorizer.Len())
+}
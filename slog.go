@@ -0,0 +1,372 @@
+package polo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// LogValue renders the wire of the Any as a structured value for log/slog.
+// Implements the slog.LogValuer interface for Any, see logValue for the rendering.
+func (wire Any) LogValue() slog.Value {
+	return logValue(wire)
+}
+
+// LogValue renders the wire of the Raw as a structured value for log/slog.
+// Implements the slog.LogValuer interface for Raw, see logValue for the rendering.
+func (raw Raw) LogValue() slog.Value {
+	return logValue(raw)
+}
+
+// LogValue renders the Document as a group of its decoded values (ordered by key) for log/slog.
+// Implements the slog.LogValuer interface for Document, see logValue for the rendering of values.
+func (doc Document) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(doc))
+	for _, key := range doc.Keys() {
+		attrs = append(attrs, slog.Attr{Key: key, Value: logValue(doc[key])})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// logValue renders some POLO wire as a value for log/slog without any knowledge of its schema.
+// Documents are rendered as groups with their keys and packs as groups with their indices as keys.
+// Integers, floats and booleans are rendered as their respective kinds (integers that do not fit
+// into 64 bits are rendered as decimal strings) and words are rendered as strings (or as hex if
+// they are not valid UTF-8). Raw wires are rendered as their inner wire and nulls as nil.
+// Malformed wires are rendered as their hex form.
+func logValue(wire []byte) slog.Value {
+	value, err := decodeLogValue(wire)
+	if err != nil {
+		return slog.StringValue(fmt.Sprintf("%#x", wire))
+	}
+
+	return value
+}
+
+// decodeLogValue decodes some POLO wire into a value for log/slog. Returns an error if the wire is malformed.
+func decodeLogValue(wire []byte) (slog.Value, error) {
+	if len(wire) == 0 {
+		return slog.AnyValue(nil), nil
+	}
+
+	rb, err := newreadbuffer(wire)
+	if err != nil {
+		return slog.Value{}, err
+	}
+
+	switch rb.wire {
+	case WireNull:
+		return slog.AnyValue(nil), nil
+
+	case WireTrue, WireFalse:
+		return slog.BoolValue(rb.wire == WireTrue), nil
+
+	case WirePosInt, WireNegInt:
+		bigint, err := rb.decodeBigInt()
+		if err != nil {
+			return slog.Value{}, err
+		}
+
+		switch {
+		case bigint.IsInt64():
+			return slog.Int64Value(bigint.Int64()), nil
+		case bigint.IsUint64():
+			return slog.Uint64Value(bigint.Uint64()), nil
+		default:
+			return slog.StringValue(bigint.String()), nil
+		}
+
+	case WireFloat:
+		switch len(rb.data) {
+		case 4:
+			float, err := rb.decodeFloat32()
+			return slog.Float64Value(float64(float)), err
+		default:
+			float, err := rb.decodeFloat64()
+			return slog.Float64Value(float), err
+		}
+
+	case WireWord:
+		if !utf8.Valid(rb.data) {
+			return slog.StringValue(fmt.Sprintf("%#x", rb.data)), nil
+		}
+
+		return slog.StringValue(string(rb.data)), nil
+
+	case WireRaw:
+		return decodeLogValue(rb.data)
+
+	case WireDoc:
+		doc, err := rb.decodeDocument(defaultWireConfig())
+		if err != nil {
+			return slog.Value{}, err
+		}
+
+		return doc.LogValue(), nil
+
+	case WirePack:
+		pack, err := newLoadDepolorizer(rb, defaultWireConfig())
+		if err != nil {
+			return slog.Value{}, err
+		}
+
+		attrs := make([]slog.Attr, 0, pack.Remaining())
+		for index := 0; !pack.Done(); index++ {
+			element, err := pack.DepolorizeAny()
+			if err != nil {
+				return slog.Value{}, err
+			}
+
+			value, err := decodeLogValue(element)
+			if err != nil {
+				return slog.Value{}, err
+			}
+
+			attrs = append(attrs, slog.Attr{Key: strconv.Itoa(index), Value: value})
+		}
+
+		return slog.GroupValue(attrs...), nil
+
+	default:
+		return slog.Value{}, IncompatibleWireError{fmt.Sprintf("unexpected wiretype '%v'", rb.wire)}
+	}
+}
+
+// SlogHandler is a slog.Handler that writes log records as POLO documents to an io.Writer.
+// Each record is written as a single frame (as done by Encoder) and can be read with a Decoder.
+//
+// The document for a record has the keys 'time' (unix nanoseconds, omitted if zero), 'level', 'msg'
+// and 'source' (if enabled) along with the attributes of the record. Groups are nested documents,
+// times are unix nanoseconds, durations are nanoseconds, errors are their message and other values
+// are encoded as is with Polorize, or as their string form if they cannot be encoded. HandlerOptions
+// are respected as with slog.JSONHandler, including ReplaceAttr.
+type SlogHandler struct {
+	mu      *sync.Mutex
+	encoder *Encoder
+	opts    slog.HandlerOptions
+
+	// groups are the groups opened with WithGroup
+	groups []string
+	// attrs are the attributes added with WithAttrs, along with the groups they were added in
+	attrs []groupedAttrs
+}
+
+// groupedAttrs are attributes added to a SlogHandler within some groups
+type groupedAttrs struct {
+	groups []string
+	attrs  []slog.Attr
+}
+
+// NewSlogHandler returns a new SlogHandler that writes to w with the given options.
+// If opts is nil, the default options are used.
+func NewSlogHandler(w io.Writer, opts *slog.HandlerOptions) *SlogHandler {
+	handler := &SlogHandler{mu: new(sync.Mutex), encoder: NewEncoder(w)}
+	if opts != nil {
+		handler.opts = *opts
+	}
+
+	return handler
+}
+
+// Enabled reports whether the handler handles records at the given level.
+// Implements the slog.Handler interface for SlogHandler.
+func (handler *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minimum := slog.LevelInfo
+	if handler.opts.Level != nil {
+		minimum = handler.opts.Level.Level()
+	}
+
+	return level >= minimum
+}
+
+// WithAttrs returns a new SlogHandler whose records include the given attributes.
+// Implements the slog.Handler interface for SlogHandler.
+func (handler *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return handler
+	}
+
+	clone := *handler
+	clone.attrs = append(slices.Clip(handler.attrs), groupedAttrs{slices.Clip(handler.groups), attrs})
+
+	return &clone
+}
+
+// WithGroup returns a new SlogHandler whose subsequent attributes are nested in the given group.
+// Implements the slog.Handler interface for SlogHandler.
+func (handler *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return handler
+	}
+
+	clone := *handler
+	clone.groups = append(slices.Clip(handler.groups), name)
+
+	return &clone
+}
+
+// Handle writes a log record as a POLO document.
+// Implements the slog.Handler interface for SlogHandler.
+func (handler *SlogHandler) Handle(_ context.Context, record slog.Record) error {
+	root := make(logNode)
+
+	// Add the builtin attributes
+	if !record.Time.IsZero() {
+		handler.add(root, nil, slog.Int64(slog.TimeKey, record.Time.UnixNano()))
+	}
+
+	handler.add(root, nil, slog.String(slog.LevelKey, record.Level.String()))
+	handler.add(root, nil, slog.String(slog.MessageKey, record.Message))
+
+	if handler.opts.AddSource && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		handler.add(root, nil, slog.Group(slog.SourceKey,
+			slog.String("function", frame.Function),
+			slog.String("file", frame.File),
+			slog.Int("line", frame.Line),
+		))
+	}
+
+	// Add the attributes of the handler
+	for _, grouped := range handler.attrs {
+		for _, attr := range grouped.attrs {
+			handler.add(root.group(grouped.groups), grouped.groups, attr)
+		}
+	}
+
+	// Add the attributes of the record
+	record.Attrs(func(attr slog.Attr) bool {
+		handler.add(root.group(handler.groups), handler.groups, attr)
+		return true
+	})
+
+	wire, err := Polorize(root.document())
+	if err != nil {
+		return err
+	}
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+
+	return handler.encoder.WriteFrame(wire)
+}
+
+// add encodes an attribute into a node, with the groups that the node is nested in
+func (handler *SlogHandler) add(node logNode, groups []string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+
+	// Replace the attribute if required (groups are not replaced)
+	if handler.opts.ReplaceAttr != nil && attr.Value.Kind() != slog.KindGroup {
+		attr = handler.opts.ReplaceAttr(groups, attr)
+		attr.Value = attr.Value.Resolve()
+	}
+
+	// Empty attributes are ignored
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		// Empty groups are ignored
+		members := attr.Value.Group()
+		if len(members) == 0 {
+			return
+		}
+
+		// Groups with an empty key are inlined
+		child, childGroups := node, groups
+		if attr.Key != "" {
+			child, childGroups = node.group([]string{attr.Key}), append(slices.Clip(groups), attr.Key)
+		}
+
+		for _, member := range members {
+			handler.add(child, childGroups, member)
+		}
+
+		return
+	}
+
+	node[attr.Key] = logAttrRaw(attr.Value)
+}
+
+// logAttrRaw encodes a (non-group) value of a slog attribute into its POLO form
+func logAttrRaw(value slog.Value) Raw {
+	var object any
+
+	switch value.Kind() {
+	case slog.KindTime:
+		object = value.Time().UnixNano()
+	case slog.KindDuration:
+		object = value.Duration().Nanoseconds()
+	case slog.KindFloat64:
+		// NaN cannot be encoded as a float
+		if float := value.Float64(); math.IsNaN(float) {
+			object = value.String()
+		} else {
+			object = float
+		}
+	default:
+		object = value.Any()
+
+		// Errors are encoded as their message, unless they are Polorizable,
+		// as they usually have no exported fields to be encoded with
+		if err, ok := object.(error); ok {
+			if _, custom := object.(Polorizable); !custom {
+				object = err.Error()
+			}
+		}
+	}
+
+	// Values that cannot be encoded are encoded as their string form
+	wire, err := Polorize(object)
+	if err != nil {
+		wire, _ = Polorize(value.String())
+	}
+
+	return wire
+}
+
+// logNode is a document that is being built for a log record.
+// Its values are either the Raw form of attributes or nested logNode groups.
+type logNode map[string]any
+
+// group returns the nested node for the given groups, creating it if required
+func (node logNode) group(groups []string) logNode {
+	for _, name := range groups {
+		child, ok := node[name].(logNode)
+		if !ok {
+			child = make(logNode)
+			node[name] = child
+		}
+
+		node = child
+	}
+
+	return node
+}
+
+// document converts the node into a Document, omitting any nested groups that are empty
+func (node logNode) document() Document {
+	doc := make(Document, len(node))
+
+	for key, value := range node {
+		switch value := value.(type) {
+		case Raw:
+			doc.SetRaw(key, value)
+		case logNode:
+			if nested := value.document(); len(nested) != 0 {
+				doc.SetRaw(key, nested.Bytes())
+			}
+		}
+	}
+
+	return doc
+}
@@ -0,0 +1,169 @@
+package polo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogValue(t *testing.T) {
+	huge, _ := new(big.Int).SetString("100000000000000000000", 10)
+
+	doc := make(Document)
+	require.NoError(t, doc.Set("name", "orange"))
+	require.NoError(t, doc.Set("count", uint64(300)))
+	require.NoError(t, doc.Set("delta", -20))
+	require.NoError(t, doc.Set("huge", huge))
+	require.NoError(t, doc.Set("ok", true))
+	require.NoError(t, doc.Set("ratio", 0.5))
+	require.NoError(t, doc.Set("bytes", []byte{0xff, 0xfe}))
+	require.NoError(t, doc.Set("list", []string{"a", "b"}))
+	doc.SetRaw("none", Raw{0})
+
+	value := doc.LogValue()
+	require.Equal(t, slog.KindGroup, value.Kind())
+
+	rendered := make(map[string]slog.Value)
+	for _, attr := range value.Group() {
+		rendered[attr.Key] = attr.Value
+	}
+
+	assert.Equal(t, "orange", rendered["name"].String())
+	assert.Equal(t, int64(300), rendered["count"].Int64())
+	assert.Equal(t, int64(-20), rendered["delta"].Int64())
+	assert.Equal(t, "100000000000000000000", rendered["huge"].String())
+	assert.True(t, rendered["ok"].Bool())
+	assert.Equal(t, 0.5, rendered["ratio"].Float64())
+	assert.Equal(t, "0xfffe", rendered["bytes"].String())
+	assert.Nil(t, rendered["none"].Any())
+
+	list := rendered["list"].Group()
+	require.Len(t, list, 2)
+	assert.Equal(t, "0", list[0].Key)
+	assert.Equal(t, "b", list[1].Value.String())
+
+	// Keys are rendered in sorted order
+	assert.Equal(t, "bytes", value.Group()[0].Key)
+
+	// Any and Raw render their wires
+	wire, err := Polorize(doc)
+	require.NoError(t, err)
+	assert.True(t, Any(wire).LogValue().Equal(value))
+	assert.True(t, Raw(wire).LogValue().Equal(value))
+
+	// Malformed wires render as hex
+	assert.Equal(t, "0x0e3f", Any{0x0e, 0x3f}.LogValue().String())
+
+	// Logging a Document renders its decoded values
+	var buffer bytes.Buffer
+	slog.New(slog.NewTextHandler(&buffer, nil)).Info("fruit", "doc", doc)
+	assert.Contains(t, buffer.String(), "doc.name=orange")
+	assert.Contains(t, buffer.String(), "doc.list.1=b")
+}
+
+func TestSlogHandler(t *testing.T) {
+	var stream bytes.Buffer
+
+	logger := slog.New(NewSlogHandler(&stream, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	logger = logger.With("service", "orchard").WithGroup("request")
+
+	logger.Debug("picked",
+		"fruit", "orange",
+		"count", 3,
+		"elapsed", 2*time.Second,
+		"err", errors.New("disk full"),
+		slog.Group("tree", "row", 4, "col", 5),
+		slog.Group("empty"),
+	)
+
+	record := make(Document)
+	require.NoError(t, NewDecoder(&stream).Decode(&record))
+
+	assert.Equal(t, []string{"level", "msg", "request", "service", "time"}, record.Keys())
+
+	level, err := DocGet[string](record, "level")
+	require.NoError(t, err)
+	assert.Equal(t, "DEBUG", level)
+
+	message, err := DocGet[string](record, "msg")
+	require.NoError(t, err)
+	assert.Equal(t, "picked", message)
+
+	timestamp, err := DocGet[int64](record, "time")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), time.Unix(0, timestamp), time.Minute)
+
+	request, err := DocGet[Document](record, "request")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"count", "elapsed", "err", "fruit", "tree"}, request.Keys())
+
+	message, err = DocGet[string](request, "err")
+	require.NoError(t, err)
+	assert.Equal(t, "disk full", message)
+
+	elapsed, err := DocGet[int64](request, "elapsed")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*time.Second), elapsed)
+
+	tree, err := DocGet[Document](request, "tree")
+	require.NoError(t, err)
+
+	row, err := DocGet[int](tree, "row")
+	require.NoError(t, err)
+	assert.Equal(t, 4, row)
+}
+
+func TestSlogHandler_Options(t *testing.T) {
+	var stream bytes.Buffer
+
+	handler := NewSlogHandler(&stream, &slog.HandlerOptions{
+		AddSource: true,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			// Drop the time and redact secrets
+			switch attr.Key {
+			case slog.TimeKey:
+				return slog.Attr{}
+			case "secret":
+				return slog.String("secret", "redacted")
+			}
+
+			return attr
+		},
+	})
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelDebug))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelInfo))
+
+	logger := slog.New(handler)
+	logger.Debug("ignored")
+	logger.Info("login", "secret", "hunter2", "user", struct{ Name string }{"alice"})
+
+	record := make(Document)
+	require.NoError(t, NewDecoder(&stream).Decode(&record))
+	assert.Equal(t, []string{"level", "msg", "secret", "source", "user"}, record.Keys())
+
+	secret, err := DocGet[string](record, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "redacted", secret)
+
+	user, err := DocGet[struct{ Name string }](record, "user")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Name)
+
+	source, err := DocGet[Document](record, "source")
+	require.NoError(t, err)
+
+	function, err := DocGet[string](source, "function")
+	require.NoError(t, err)
+	assert.Contains(t, function, "TestSlogHandler_Options")
+
+	// Only one record was written
+	assert.Equal(t, 0, stream.Len())
+}
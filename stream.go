@@ -0,0 +1,123 @@
+package polo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultFrameLimit is the maximum size of a frame that can be read by a Decoder, unless changed with SetLimit
+const DefaultFrameLimit = 64 << 20
+
+// Encoder writes POLO encoded objects to an output stream as a sequence of frames.
+// Each frame is the length of the wire of an object as a varint, followed by the wire.
+type Encoder struct {
+	w   io.Writer
+	cfg []EncodingOptions
+
+	// buffer is reused for encoding each frame
+	buffer []byte
+}
+
+// NewEncoder returns a new Encoder that writes frames to w.
+// Accepts EncodingOptions to modify the encoding behaviour of every object.
+func NewEncoder(w io.Writer, options ...EncodingOptions) *Encoder {
+	return &Encoder{w: w, cfg: options}
+}
+
+// Encode serializes an object into its POLO byte form and writes it to the stream as a single frame.
+// Returns an error if the object cannot be serialized or if the frame cannot be written.
+func (encoder *Encoder) Encode(object any) error {
+	wire, err := Polorize(object, encoder.cfg...)
+	if err != nil {
+		return err
+	}
+
+	return encoder.WriteFrame(wire)
+}
+
+// WriteFrame writes some wire to the stream as a single frame, without checking that it is valid POLO.
+func (encoder *Encoder) WriteFrame(wire []byte) error {
+	// Assemble the length prefix and the wire to write them with a single call
+	encoder.buffer = appendVarint(encoder.buffer[:0], uint64(len(wire)))
+	encoder.buffer = append(encoder.buffer, wire...)
+
+	_, err := encoder.w.Write(encoder.buffer)
+
+	return err
+}
+
+// Decoder reads POLO encoded objects from an input stream that is a sequence of frames written by an Encoder.
+type Decoder struct {
+	r     byteReader
+	cfg   []EncodingOptions
+	limit int
+}
+
+// byteReader is an io.Reader that can also read single bytes
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// NewDecoder returns a new Decoder that reads frames from r.
+// If r does not implement io.ByteReader, it is wrapped with a bufio.Reader
+// and the Decoder may read data from r beyond the frames that are requested.
+// Accepts EncodingOptions to modify the decoding behaviour of every object.
+func NewDecoder(r io.Reader, options ...EncodingOptions) *Decoder {
+	reader, ok := r.(byteReader)
+	if !ok {
+		reader = bufio.NewReader(r)
+	}
+
+	return &Decoder{r: reader, cfg: options, limit: DefaultFrameLimit}
+}
+
+// SetLimit sets the maximum size of a frame that can be read by the Decoder.
+// Frames that are larger than the limit are rejected before they are read.
+func (decoder *Decoder) SetLimit(limit int) {
+	decoder.limit = limit
+}
+
+// Decode reads the next frame from the stream and deserializes it into an object which must be a pointer.
+// Returns io.EOF if the stream ends before the frame and an error if the frame cannot be read or decoded.
+func (decoder *Decoder) Decode(object any) error {
+	wire, err := decoder.ReadFrame()
+	if err != nil {
+		return err
+	}
+
+	return Depolorize(object, wire, decoder.cfg...)
+}
+
+// ReadFrame reads the wire of the next frame from the stream, without checking that it is valid POLO.
+// Returns io.EOF if the stream ends before the frame and io.ErrUnexpectedEOF if it ends within the frame.
+func (decoder *Decoder) ReadFrame() ([]byte, error) {
+	// Varints in frames have the same encoding as binary.Uvarint, which
+	// returns io.EOF only if the stream ended before the first byte
+	size, err := binary.ReadUvarint(decoder.r)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("malformed frame: %w", err)
+	}
+
+	if size > uint64(decoder.limit) {
+		return nil, fmt.Errorf("malformed frame: size %v exceeds limit %v", size, decoder.limit)
+	}
+
+	wire := make([]byte, size)
+	if _, err = io.ReadFull(decoder.r, wire); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return wire, nil
+}
@@ -0,0 +1,96 @@
+package polo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoderDecoder(t *testing.T) {
+	f := fuzz.New().NilChance(0.1)
+
+	objects := make([]MapObject, 50)
+	for i := range objects {
+		f.Fuzz(&objects[i])
+	}
+
+	var stream bytes.Buffer
+
+	encoder := NewEncoder(&stream)
+	for _, object := range objects {
+		require.NoError(t, encoder.Encode(object))
+	}
+
+	// Frames are the wire of each object prefixed with its length
+	wire, err := Polorize(objects[0])
+	require.NoError(t, err)
+	frame := append(appendVarint(nil, uint64(len(wire))), wire...)
+	assert.Equal(t, frame, stream.Bytes()[:len(frame)])
+
+	// Decode from a reader that is not an io.ByteReader
+	decoder := NewDecoder(iotest.OneByteReader(&stream))
+	for _, object := range objects {
+		decoded := new(MapObject)
+		require.NoError(t, decoder.Decode(decoded))
+		assert.Equal(t, object, *decoded)
+	}
+
+	assert.Equal(t, io.EOF, decoder.Decode(new(MapObject)))
+}
+
+func TestEncoderDecoder_Options(t *testing.T) {
+	type Fruit struct {
+		Name string
+	}
+
+	var stream bytes.Buffer
+
+	require.NoError(t, NewEncoder(&stream, DocStructs()).Encode(Fruit{"orange"}))
+
+	expected, err := Polorize(Fruit{"orange"}, DocStructs())
+	require.NoError(t, err)
+	assert.Equal(t, expected, stream.Bytes()[1:])
+
+	fruit := new(Fruit)
+	require.NoError(t, NewDecoder(&stream, DocStructs(), DisallowUnknownFields()).Decode(fruit))
+	assert.Equal(t, Fruit{"orange"}, *fruit)
+}
+
+func TestDecoder_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		limit int
+		err   error
+		msg   string
+	}{
+		{"Empty", []byte{}, 0, io.EOF, ""},
+		{"Partial Size", []byte{0x80}, 0, io.ErrUnexpectedEOF, ""},
+		{"Partial Wire", []byte{3, 6, 1}, 0, io.ErrUnexpectedEOF, ""},
+		{"Overflow", bytes.Repeat([]byte{0xFF}, 11), 0, nil, "malformed frame: binary: varint overflows a 64-bit integer"},
+		{"Limit", []byte{10, 6, 1, 2, 3, 4, 5, 6, 7, 8, 9}, 5, nil, "malformed frame: size 10 exceeds limit 5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decoder := NewDecoder(bytes.NewReader(test.data))
+			if test.limit != 0 {
+				decoder.SetLimit(test.limit)
+			}
+
+			_, err := decoder.ReadFrame()
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err), err)
+				return
+			}
+
+			assert.EqualError(t, err, test.msg)
+		})
+	}
+}
@@ -1,132 +1,273 @@
 package polo
 
-import "reflect"
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"slices"
+)
+
+var (
+	// errArrayLength is an error for when two array values of different lengths are compared
+	errArrayLength = errors.New("array length must equal")
+	// errUnsupportedCompare is an error for when two values of a kind that cannot be ordered are compared
+	errUnsupportedCompare = errors.New("unsupported key compare")
+)
 
 // ValueSort is used by the sort package to sort a slice of reflect.Value objects.
 // Assumes that the reflect.Value objects can only be types which are comparable
 // i.e, can be used as a map key. (will panic otherwise)
 func ValueSort(keys []reflect.Value) func(int, int) bool {
 	return func(i int, j int) bool {
-		a, b := keys[i], keys[j]
-		if a.Kind() == reflect.Interface {
-			a, b = a.Elem(), b.Elem()
-		}
-
-		return ValueLt(a, b)
+		return ValueLt(keys[i], keys[j])
 	}
 }
 
 // ValueLt is returns a < b, for two reflected values a & b
+// Assumes that a and b can only have a type that is comparable. (will panic otherwise).
 func ValueLt(a, b reflect.Value) bool {
-	switch a.Kind() {
-	case reflect.Bool:
-		return b.Bool()
+	return ValueCmp(a, b) < 0
+}
 
-	case reflect.String:
-		return a.String() < b.String()
+// ValueCmp returns an integer representing the comparison between two reflect.Value objects.
+// Assumes that a and b can only have a type that is comparable. (will panic otherwise).
+// Returns 1 (a > b); 0 (a == b); -1 (a < b)
+func ValueCmp(a, b reflect.Value) int {
+	result, err := compareValues(a, b)
+	if err != nil {
+		panic(err.Error())
+	}
 
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return a.Int() < b.Int()
+	return result
+}
 
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return a.Uint() < b.Uint()
+// SortableKey is a constraint for the kinds of keys that can be sorted with SortKeys.
+// Keys can either be reflected values (directly or indexed with Key) or their encoded forms.
+type SortableKey interface {
+	reflect.Value | Key | []byte | Any | Raw
+}
 
-	case reflect.Float32, reflect.Float64:
-		return a.Float() < b.Float()
+// SortKeys sorts a slice of keys in place into their deterministic encoding order.
+//   - Reflected values are ordered by their natural value ordering (see ValueCmp)
+//   - Indexed keys are ordered by their values with ties broken by their original index
+//   - Encoded keys are ordered by a lexicographical comparison of their bytes
+//
+// Returns an error if any two keys cannot be compared, in which case the order of keys is undefined.
+func SortKeys[K SortableKey](keys []K) error {
+	switch keys := any(keys).(type) {
+	case []reflect.Value:
+		return sortFunc(keys, compareValues)
+
+	case []Key:
+		return sortFunc(keys, func(a, b Key) (int, error) {
+			result, err := compareValues(a.val, b.val)
+			if err != nil || result != 0 {
+				return result, err
+			}
 
-	case reflect.Array:
-		if a.Len() != b.Len() {
-			panic("array length must equal")
+			return compareOrdered(int64(a.idx), int64(b.idx)), nil
+		})
+
+	case [][]byte:
+		slices.SortFunc(keys, bytes.Compare)
+	case []Any:
+		slices.SortFunc(keys, func(a, b Any) int { return bytes.Compare(a, b) })
+	case []Raw:
+		slices.SortFunc(keys, func(a, b Raw) int { return bytes.Compare(a, b) })
+	}
+
+	return nil
+}
+
+// sortFunc sorts a slice in place with slices.SortFunc for a comparison function that can fail.
+// Returns the first error encountered while comparing, in which case the order of elements is undefined.
+func sortFunc[E any](elements []E, cmp func(E, E) (int, error)) (err error) {
+	slices.SortFunc(elements, func(a, b E) int {
+		// Short circuit the remaining comparisons if an error has occurred
+		if err != nil {
+			return 0
 		}
 
-		for i := 0; i < a.Len(); i++ {
-			result := ValueCmp(a.Index(i), b.Index(i))
-			if result == 0 {
-				continue
+		result, cmperr := cmp(a, b)
+		if cmperr != nil {
+			err = cmperr
+		}
+
+		return result
+	})
+
+	return err
+}
+
+// sortKeyTies orders the runs of sorted map keys that compare as equal but are distinct keys of the map,
+// such as pointers to equal values or structs that differ only in their unexported fields. Such keys are
+// ordered by their encoded bytes with ties broken by the encoded bytes of their map values, so that the
+// encoding of the map does not depend on the iteration order of its keys.
+func sortKeyTies(mapping reflect.Value, keys []reflect.Value, cfg wireConfig) error {
+	for start := 0; start < len(keys); {
+		end := start + 1
+		for end < len(keys) {
+			if result, _ := compareValues(keys[start], keys[end]); result != 0 {
+				break
 			}
 
-			return result < 0
+			end++
+		}
+
+		if end-start > 1 {
+			if err := sortEncodedEntries(mapping, keys[start:end], cfg); err != nil {
+				return err
+			}
 		}
+
+		start = end
 	}
 
-	panic("unsupported key compare")
+	return nil
 }
 
-// ValueCmp returns an integer representing the comparison between two reflect.Value objects.
-// Assumes that a and b can only have a type that is comparable. (will panic otherwise).
-// Returns 1 (a > b); 0 (a == b); -1 (a < b)
-func ValueCmp(a, b reflect.Value) int {
+// sortEncodedEntries sorts a slice of map keys in place by the encoded bytes
+// of each key, with ties broken by the encoded bytes of their map values.
+func sortEncodedEntries(mapping reflect.Value, keys []reflect.Value, cfg wireConfig) error {
+	type entry struct {
+		key      reflect.Value
+		kwb, vwb []byte
+	}
+
+	entries := make([]entry, len(keys))
+
+	for i, key := range keys {
+		kp := &Polorizer{wb: &writebuffer{}, cfg: cfg}
+		if err := kp.polorizeValue(key); err != nil {
+			return err
+		}
+
+		vp := &Polorizer{wb: &writebuffer{}, cfg: cfg}
+		if err := vp.polorizeValue(mapping.MapIndex(key)); err != nil {
+			return err
+		}
+
+		entries[i] = entry{key, kp.Bytes(), vp.Bytes()}
+	}
+
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		if result := bytes.Compare(a.kwb, b.kwb); result != 0 {
+			return result
+		}
+
+		return bytes.Compare(a.vwb, b.vwb)
+	})
+
+	for i, entry := range entries {
+		keys[i] = entry.key
+	}
+
+	return nil
+}
+
+// compareValues returns an integer representing the comparison between two reflect.Value objects.
+// Supports every kind that can be encoded as a map key i.e, booleans, numbers, strings, arrays,
+// structs and pointers to these. Structs are compared field by field in their encoding order
+// and pointers are compared by the value they point to with nil pointers ordered first.
+// Returns 1 (a > b); 0 (a == b); -1 (a < b) or an error if the values cannot be compared.
+func compareValues(a, b reflect.Value) (int, error) {
 	if a.Kind() == reflect.Interface {
 		a, b = a.Elem(), b.Elem()
 	}
 
+	// Values with different dynamic types (from interface keys) cannot be ordered
+	if !a.IsValid() || !b.IsValid() || a.Kind() != b.Kind() {
+		return 0, errUnsupportedCompare
+	}
+
 	switch a.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		av, bv := a.Int(), b.Int()
+	case reflect.Bool:
+		av, bv := a.Bool(), b.Bool()
 
 		switch {
-		case av < bv:
-			return -1
 		case av == bv:
-			return 0
-		case av > bv:
-			return 1
+			return 0, nil
+		case bv:
+			return -1, nil
+		default:
+			return 1, nil
 		}
 
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		av, bv := a.Uint(), b.Uint()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int()), nil
 
-		switch {
-		case av < bv:
-			return -1
-		case av == bv:
-			return 0
-		case av > bv:
-			return 1
-		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareOrdered(a.Uint(), b.Uint()), nil
 
 	case reflect.Float32, reflect.Float64:
-		av, bv := a.Float(), b.Float()
-
-		switch {
-		case av < bv:
-			return -1
-		case av == bv:
-			return 0
-		case av > bv:
-			return 1
-		}
+		return compareOrdered(a.Float(), b.Float()), nil
 
 	case reflect.String:
-		av, bv := a.String(), b.String()
-
-		switch {
-		case av < bv:
-			return -1
-		case av == bv:
-			return 0
-		case av > bv:
-			return 1
-		}
+		return compareOrdered(a.String(), b.String()), nil
 
 	case reflect.Array:
 		if a.Len() != b.Len() {
-			panic("array length must equal")
+			return 0, errArrayLength
 		}
 
 		for i := 0; i < a.Len(); i++ {
-			result := ValueCmp(a.Index(i), b.Index(i))
-			if result == 0 {
+			result, err := compareValues(a.Index(i), b.Index(i))
+			if err != nil || result != 0 {
+				return result, err
+			}
+		}
+
+		return 0, nil
+
+	case reflect.Struct:
+		t := a.Type()
+		if t != b.Type() {
+			return 0, errUnsupportedCompare
+		}
+
+		for i, tag := range fieldTags(t) {
+			// Skip the field if it is not exported or if it
+			// is manually tagged to be skipped with a '-' tag
+			if tag.skip {
 				continue
 			}
 
-			return result
+			result, err := compareValues(a.Field(i), b.Field(i))
+			if err != nil || result != 0 {
+				return result, err
+			}
 		}
 
-		return 0
+		return 0, nil
+
+	case reflect.Ptr:
+		// Nil pointers are encoded as WireNull and are ordered first
+		switch {
+		case a.IsNil() && b.IsNil():
+			return 0, nil
+		case a.IsNil():
+			return -1, nil
+		case b.IsNil():
+			return 1, nil
+		}
+
+		return compareValues(a.Elem(), b.Elem())
 	}
 
-	panic("unsupported key compare")
+	return 0, errUnsupportedCompare
+}
+
+// compareOrdered returns an integer representing the comparison between two ordered values.
+// Returns 1 (a > b); 0 (a == b); -1 (a < b)
+func compareOrdered[T int64 | uint64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
 }
 
 // Key is an indexed reflect value. Preserves the original index of key.
@@ -148,54 +289,20 @@ func (key Key) Index() int {
 }
 
 // KeySort accepts a slice of Key values and a channel to return them on.
-// They are returned in sorted order and are merge sorted recursively.
+// They are returned in sorted order and the channel is closed after the last key.
+// Panics if any two keys cannot be compared.
+//
+// Deprecated: KeySort is a wrapper around SortKeys, which should be used instead.
 func KeySort(keys []Key, ch chan Key) {
 	defer close(ch)
 
-	// If there are no elements, return
-	if len(keys) == 0 {
-		return
+	// Sort a copy of the keys to avoid mutating the given slice
+	sorted := slices.Clone(keys)
+	if err := SortKeys(sorted); err != nil {
+		panic(err.Error())
 	}
 
-	// If there is only 1 element, return it
-	if len(keys) == 1 {
-		ch <- keys[0]
-		return
-	}
-
-	// Determine the midpoint
-	mid := len(keys) / 2
-
-	// Start sorting the left side
-	left := make(chan Key)
-	go KeySort(keys[:mid], left)
-	// Start sorting the right side
-	right := make(chan Key)
-	go KeySort(keys[mid:], right)
-
-	// Get initial values from the both channels
-	lv, lok := <-left
-	rv, rok := <-right
-
-	// Iterate until both sides have returned all values
-	for lok || rok {
-		switch {
-		case lok && rok:
-			if ValueLt(lv.val, rv.val) {
-				ch <- lv
-				lv, lok = <-left
-			} else {
-				ch <- rv
-				rv, rok = <-right
-			}
-
-		case lok && !rok:
-			ch <- lv
-			lv, lok = <-left
-
-		case !lok && rok:
-			ch <- rv
-			rv, rok = <-right
-		}
+	for _, key := range sorted {
+		ch <- key
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValueSort_Panics(t *testing.T) {
@@ -47,6 +48,119 @@ func TestValueCmp_Panics(t *testing.T) {
 	})
 }
 
+func TestValueCmp(t *testing.T) {
+	type compositeKey struct {
+		Kind  string
+		Index [2]uint16
+		Flag  bool
+	}
+
+	value := uint8(5)
+
+	tests := []struct {
+		name   string
+		a, b   any
+		result int
+	}{
+		{"Bool False True", false, true, -1},
+		{"Bool True False", true, false, 1},
+		{"Bool Equal", true, true, 0},
+		{"Nested Array", [2][2]int{{1, 2}, {3, 4}}, [2][2]int{{1, 2}, {3, 3}}, 1},
+		{"Struct First Field", compositeKey{"a", [2]uint16{9, 9}, true}, compositeKey{"b", [2]uint16{}, false}, -1},
+		{"Struct Later Field", compositeKey{"a", [2]uint16{1, 2}, true}, compositeKey{"a", [2]uint16{1, 2}, false}, 1},
+		{"Struct Equal", compositeKey{"a", [2]uint16{1, 2}, true}, compositeKey{"a", [2]uint16{1, 2}, true}, 0},
+		{"Pointer Nil", (*uint8)(nil), &value, -1},
+		{"Pointer Value", &value, new(uint8), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.result, ValueCmp(reflect.ValueOf(tt.a), reflect.ValueOf(tt.b)))
+			assert.Equal(t, tt.result < 0, ValueLt(reflect.ValueOf(tt.a), reflect.ValueOf(tt.b)))
+		})
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	t.Run("Reflected Values", func(t *testing.T) {
+		keys := []reflect.Value{reflect.ValueOf(3), reflect.ValueOf(-1), reflect.ValueOf(2)}
+		require.Nil(t, SortKeys(keys))
+
+		sorted := make([]int64, 0, len(keys))
+		for _, key := range keys {
+			sorted = append(sorted, key.Int())
+		}
+
+		assert.Equal(t, []int64{-1, 2, 3}, sorted)
+	})
+
+	t.Run("Indexed Keys", func(t *testing.T) {
+		keys := []Key{NewKey(0, "b"), NewKey(1, "a"), NewKey(3, "b"), NewKey(2, "b")}
+		require.Nil(t, SortKeys(keys))
+		assert.Equal(t, []Key{NewKey(1, "a"), NewKey(0, "b"), NewKey(2, "b"), NewKey(3, "b")}, keys)
+	})
+
+	t.Run("Encoded Keys", func(t *testing.T) {
+		keys := []Raw{{6, 98}, {3, 1}, {6, 97, 97}, {6, 97}}
+		require.Nil(t, SortKeys(keys))
+		assert.Equal(t, []Raw{{3, 1}, {6, 97}, {6, 97, 97}, {6, 98}}, keys)
+	})
+}
+
+func TestSortKeyTies(t *testing.T) {
+	t.Run("Equal Pointees", func(t *testing.T) {
+		a, b := "k", "k"
+		mapping := map[*string]int{&a: 1, &b: 2}
+
+		expected, err := Polorize(mapping)
+		require.Nil(t, err)
+
+		for i := 0; i < 100; i++ {
+			wire, err := Polorize(mapping)
+			require.Nil(t, err)
+			require.Equal(t, expected, wire)
+		}
+
+		size, err := SizeOf(mapping)
+		require.Nil(t, err)
+		assert.Equal(t, len(expected), size)
+	})
+
+	t.Run("Unexported Fields", func(t *testing.T) {
+		type hiddenKey struct {
+			Name   string
+			hidden int
+		}
+
+		mapping := map[hiddenKey]int{{"k", 1}: 1, {"k", 2}: 2, {"k", 3}: 3}
+
+		expected, err := Polorize(mapping)
+		require.Nil(t, err)
+
+		for i := 0; i < 100; i++ {
+			wire, err := Polorize(mapping)
+			require.Nil(t, err)
+			require.Equal(t, expected, wire)
+		}
+	})
+}
+
+func TestSortKeys_Errors(t *testing.T) {
+	t.Run("Unsupported Field", func(t *testing.T) {
+		type channelKey struct {
+			Ch chan int
+		}
+
+		keys := []reflect.Value{reflect.ValueOf(channelKey{}), reflect.ValueOf(channelKey{})}
+		assert.ErrorIs(t, SortKeys(keys), errUnsupportedCompare)
+	})
+
+	t.Run("Mixed Interface", func(t *testing.T) {
+		mapping := map[any]int{"a": 1, 2: 2}
+		assert.ErrorIs(t, SortKeys(reflect.ValueOf(mapping).MapKeys()), errUnsupportedCompare)
+	})
+}
+
 func TestKey(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,559 @@
+package polo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateVectors is the flag to regenerate the JSON export of the test vectors in testdata/vectors.
+// It is set by running the tests with 'go test -run TestVectors -update-vectors'.
+var updateVectors = flag.Bool("update-vectors", false, "regenerate the JSON test vectors in testdata/vectors")
+
+// vectorsVersion is the version of the test vectors corpus.
+// It must be incremented when existing vectors are changed or removed (but not when vectors are added).
+const vectorsVersion = 1
+
+// vectorsDir is the directory of the JSON export of the current version of the test vectors
+var vectorsDir = filepath.Join("testdata", "vectors", fmt.Sprintf("v%d", vectorsVersion))
+
+// Error classes for test vectors that must fail to decode.
+// These are independent of the error messages so that they can be checked by other implementations.
+const (
+	// errClassMalformedTag is for wires with a tag (or load tag) that is not a valid varint
+	errClassMalformedTag = "malformed-tag"
+	// errClassMalformedLoad is for compound wires whose load is missing or invalid
+	errClassMalformedLoad = "malformed-load"
+	// errClassInsufficientWire is for compound wires that have less data than declared in their load
+	errClassInsufficientWire = "insufficient-wire"
+	// errClassIncompatibleWire is for wires that cannot be decoded into the type (such as an unexpected wire type)
+	errClassIncompatibleWire = "incompatible-wire"
+	// errClassIncompatibleValue is for wires that decode into a value that is invalid for the type (such as overflows)
+	errClassIncompatibleValue = "incompatible-value"
+)
+
+// errorClass returns the error class of an error from decoding a test vector.
+// Returns an empty string if the error does not belong to any class.
+func errorClass(err error) string {
+	var (
+		malformedTag      MalformedTagError
+		malformedLoad     MalformedLoadError
+		incompatibleWire  IncompatibleWireError
+		incompatibleValue IncompatibleValueError
+	)
+
+	switch {
+	case errors.As(err, &malformedTag):
+		return errClassMalformedTag
+	case errors.As(err, &malformedLoad):
+		return errClassMalformedLoad
+	case errors.Is(err, ErrInsufficientWire):
+		return errClassInsufficientWire
+	case errors.As(err, &incompatibleWire):
+		return errClassIncompatibleWire
+	case errors.As(err, &incompatibleValue):
+		return errClassIncompatibleValue
+	default:
+		return ""
+	}
+}
+
+// vector is a POLO test vector, which is a wire and the value it encodes for some type.
+type vector struct {
+	name string
+	wire []byte
+	// value is the object encoded by the wire. Its type is the type that the wire is decoded into.
+	// For vectors with an error class, it is the zero value of the type that fails to decode.
+	value any
+	// decodeOnly is set for wires that decode into the value but are not its canonical encoding
+	decodeOnly bool
+	// err is the error class for wires that must fail to decode
+	err string
+}
+
+// vectorCategory is a named group of test vectors, which are exported together into one JSON file
+type vectorCategory struct {
+	name    string
+	vectors []vector
+}
+
+type VectorFruit struct {
+	Name string
+	Cost uint64
+	Tags []string
+}
+
+type VectorOptional struct {
+	Count *int32
+	Note  string
+}
+
+// testVectors returns the corpus of POLO test vectors, grouped by category
+func testVectors() []vectorCategory {
+	pow64, _ := new(big.Int).SetString("18446744073709551616", 10)
+	pow255 := new(big.Int).Lsh(big.NewInt(1), 255)
+
+	return []vectorCategory{
+		{"null", []vector{
+			{name: "null pointer", wire: []byte{0}, value: (*uint64)(nil)},
+			{name: "null list", wire: []byte{0}, value: []string(nil)},
+			{name: "null map", wire: []byte{0}, value: map[string]string(nil)},
+			{name: "null document", wire: []byte{0}, value: Document(nil)},
+			{name: "null as integer", wire: []byte{0}, value: uint64(0), decodeOnly: true},
+			{name: "null as string", wire: []byte{0}, value: "", decodeOnly: true},
+			{name: "null as bool", wire: []byte{0}, value: false, decodeOnly: true},
+			{name: "null as float", wire: []byte{0}, value: float64(0), decodeOnly: true},
+			{name: "null struct field", wire: []byte{14, 47, 0, 6, 120}, value: VectorOptional{Note: "x"}},
+		}},
+		{"bool", []vector{
+			{name: "true", wire: []byte{2}, value: true},
+			{name: "false", wire: []byte{1}, value: false},
+		}},
+		{"integer", []vector{
+			{name: "uint8 zero", wire: []byte{3}, value: uint8(0)},
+			{name: "uint8 max", wire: []byte{3, 255}, value: uint8(math.MaxUint8)},
+			{name: "uint16 max", wire: []byte{3, 255, 255}, value: uint16(math.MaxUint16)},
+			{name: "uint32 max", wire: []byte{3, 255, 255, 255, 255}, value: uint32(math.MaxUint32)},
+			{
+				name: "uint64 max", value: uint64(math.MaxUint64),
+				wire: []byte{3, 255, 255, 255, 255, 255, 255, 255, 255},
+			},
+			{name: "int8 min", wire: []byte{4, 128}, value: int8(math.MinInt8)},
+			{name: "int64 minus one", wire: []byte{4, 1}, value: int64(-1)},
+			{
+				name: "int64 min", value: int64(math.MinInt64),
+				wire: []byte{4, 128, 0, 0, 0, 0, 0, 0, 0},
+			},
+			{
+				name: "int64 max", value: int64(math.MaxInt64),
+				wire: []byte{3, 127, 255, 255, 255, 255, 255, 255, 255},
+			},
+			{name: "bigint zero", wire: []byte{3}, value: big.NewInt(0)},
+			{name: "bigint 2^64", wire: []byte{3, 1, 0, 0, 0, 0, 0, 0, 0, 0}, value: pow64},
+			{name: "bigint -2^64", wire: []byte{4, 1, 0, 0, 0, 0, 0, 0, 0, 0}, value: new(big.Int).Neg(pow64)},
+			{name: "bigint 2^255", wire: append([]byte{3, 128}, make([]byte, 31)...), value: pow255},
+		}},
+		{"float", []vector{
+			{name: "float32", wire: []byte{7, 63, 192, 0, 0}, value: float32(1.5)},
+			{name: "float32 negative", wire: []byte{7, 190, 128, 0, 0}, value: float32(-0.25)},
+			{name: "float64 zero", wire: []byte{7, 0, 0, 0, 0, 0, 0, 0, 0}, value: float64(0)},
+			{name: "float64 pi", wire: []byte{7, 64, 9, 33, 251, 84, 68, 45, 24}, value: math.Pi},
+			{name: "float64 max", wire: []byte{7, 127, 239, 255, 255, 255, 255, 255, 255}, value: math.MaxFloat64},
+			{name: "float64 subnormal", wire: []byte{7, 0, 0, 0, 0, 0, 0, 0, 1}, value: math.SmallestNonzeroFloat64},
+			{name: "float64 +inf", wire: []byte{7, 127, 240, 0, 0, 0, 0, 0, 0}, value: math.Inf(1)},
+			{name: "float64 -inf", wire: []byte{7, 255, 240, 0, 0, 0, 0, 0, 0}, value: math.Inf(-1)},
+		}},
+		{"word", []vector{
+			{name: "empty string", wire: []byte{6}, value: ""},
+			{name: "ascii string", wire: []byte{6, 104, 101, 108, 108, 111}, value: "hello"},
+			{name: "unicode string", wire: []byte{6, 104, 195, 169, 108, 108, 111, 32, 226, 156, 147}, value: "héllo ✓"},
+			{name: "empty bytes", wire: []byte{6}, value: []byte{}},
+			{name: "bytes", wire: []byte{6, 0, 1, 255}, value: []byte{0, 1, 255}},
+			{name: "byte array", wire: []byte{6, 1, 2, 3, 4}, value: [4]byte{1, 2, 3, 4}},
+		}},
+		{"raw", []vector{
+			{name: "raw", wire: []byte{5, 3, 1}, value: Raw{3, 1}},
+			{name: "any", wire: []byte{6, 120}, value: Any{6, 120}},
+		}},
+		{"pack", []vector{
+			{name: "empty list", wire: []byte{14, 15}, value: []string{}},
+			{name: "integer list", wire: []byte{14, 63, 3, 19, 35, 1, 2, 3}, value: []uint64{1, 2, 3}},
+			{name: "bool array", wire: []byte{14, 47, 2, 1}, value: [2]bool{true, false}},
+			{
+				name: "bigint list", value: []*big.Int{big.NewInt(-5), pow64},
+				wire: []byte{14, 47, 4, 19, 5, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			{
+				name: "nested list", value: [][]string{{"a"}, {}, {"b", "c"}},
+				wire: []byte{14, 63, 14, 62, 78, 31, 6, 97, 15, 47, 6, 22, 98, 99},
+			},
+			{
+				name: "string map", value: map[string]uint64{"a": 1, "b": 2},
+				wire: []byte{14, 79, 6, 19, 38, 51, 97, 1, 98, 2},
+			},
+			{
+				name: "integer map", value: map[uint64]bool{2: false, 1: true},
+				wire: []byte{14, 79, 3, 18, 19, 33, 1, 2},
+			},
+			{
+				name: "struct", value: VectorFruit{"orange", 300, []string{"citrus"}},
+				wire: []byte{
+					14, 79, 6, 99, 142, 1, 111, 114, 97, 110, 103, 101,
+					1, 44, 31, 6, 99, 105, 116, 114, 117, 115,
+				},
+			},
+		}},
+		{"document", []vector{
+			{name: "empty document", wire: []byte{13, 15}, value: Document{}},
+			{
+				name: "document", value: Document{"a": Raw{3, 1}, "b": Raw{6, 120}},
+				wire: []byte{13, 79, 6, 21, 54, 69, 97, 3, 1, 98, 6, 120},
+			},
+		}},
+		{"malformed", []vector{
+			{name: "empty wire", wire: []byte{}, value: uint64(0), err: errClassMalformedTag},
+			{
+				name: "tag varint overflow", value: uint64(0), err: errClassMalformedTag,
+				wire: []byte{255, 128, 128, 128, 128, 128, 128, 128, 128, 127, 3},
+			},
+			{name: "load tag terminated prematurely", wire: []byte{14, 175}, value: []string(nil), err: errClassMalformedTag},
+			{
+				name: "element tag terminated prematurely", value: []string(nil), err: errClassMalformedTag,
+				wire: []byte{14, 47, 6, 230, 102, 111, 111},
+			},
+			{name: "missing load tag", wire: []byte{14, 78, 3, 3}, value: []uint64(nil), err: errClassMalformedLoad},
+			{
+				name: "insufficient document data", value: Document(nil), err: errClassInsufficientWire,
+				wire: []byte{13, 63, 6, 53, 86, 101, 97, 114, 3, 123, 102, 111, 111, 6, 98, 97, 114},
+			},
+			{name: "bool from integer", wire: []byte{3, 1}, value: false, err: errClassIncompatibleWire},
+			{name: "string from pack", wire: []byte{14, 15}, value: "", err: errClassIncompatibleWire},
+			{name: "integer from word", wire: []byte{6, 1}, value: uint64(0), err: errClassIncompatibleWire},
+			{name: "reserved wire type", wire: []byte{8}, value: uint64(0), err: errClassIncompatibleWire},
+			{name: "float32 length mismatch", wire: []byte{7, 63, 192}, value: float32(0), err: errClassIncompatibleWire},
+			{
+				name: "byte array length mismatch", value: [2]byte{}, err: errClassIncompatibleWire,
+				wire: []byte{6, 255, 255, 255},
+			},
+			{name: "uint8 excess data", wire: []byte{3, 1, 0}, value: uint8(0), err: errClassIncompatibleValue},
+			{name: "int8 excess data", wire: []byte{4, 1, 0}, value: int8(0), err: errClassIncompatibleValue},
+			{
+				name: "int64 overflow", value: int64(0), err: errClassIncompatibleValue,
+				wire: []byte{3, 255, 255, 255, 255, 255, 255, 255, 255},
+			},
+			{
+				name: "int64 negative overflow", value: int64(0), err: errClassIncompatibleValue,
+				wire: []byte{4, 128, 0, 0, 0, 0, 0, 0, 1},
+			},
+			{name: "negative unsigned", wire: []byte{4, 1}, value: uint64(0), err: errClassIncompatibleWire},
+			{name: "float32 nan", wire: []byte{7, 255, 255, 0, 0}, value: float32(0), err: errClassIncompatibleValue},
+			{
+				name: "float64 nan", value: float64(0), err: errClassIncompatibleValue,
+				wire: []byte{7, 255, 255, 0, 0, 0, 0, 0, 0},
+			},
+		}},
+	}
+}
+
+// TestVectors runs every test vector against Polorize, Depolorize and Any
+func TestVectors(t *testing.T) {
+	for _, category := range testVectors() {
+		for _, vector := range category.vectors {
+			t.Run(category.name+"/"+vector.name, func(t *testing.T) {
+				target := reflect.New(reflect.TypeOf(vector.value))
+
+				// Vectors with an error class must fail to decode with an error of that class
+				if vector.err != "" {
+					err := Depolorize(target.Interface(), vector.wire)
+					require.Error(t, err)
+					assert.Equal(t, vector.err, errorClass(err), "unexpected error class for: %v", err)
+
+					return
+				}
+
+				// The wire must decode into the value
+				require.NoError(t, Depolorize(target.Interface(), vector.wire))
+				assert.Equal(t, vector.value, target.Elem().Interface())
+
+				// The value must encode into the wire, if it is canonical
+				if !vector.decodeOnly {
+					wire, err := Polorize(vector.value)
+					require.NoError(t, err)
+					assert.Equal(t, vector.wire, wire)
+				}
+
+				// The wire must be captured whole by an Any
+				var wire Any
+
+				require.NoError(t, Depolorize(&wire, vector.wire))
+				assert.Equal(t, Any(vector.wire), wire)
+			})
+		}
+	}
+}
+
+// TestVectors_Export checks that the JSON export of the test vectors in testdata/vectors is up-to-date
+func TestVectors_Export(t *testing.T) {
+	if *updateVectors {
+		require.NoError(t, os.MkdirAll(vectorsDir, 0o755))
+	}
+
+	for _, category := range testVectors() {
+		exported, err := exportVectors(category)
+		require.NoError(t, err)
+
+		path := filepath.Join(vectorsDir, category.name+".json")
+
+		if *updateVectors {
+			require.NoError(t, os.WriteFile(path, exported, 0o600))
+			continue
+		}
+
+		stored, err := os.ReadFile(path)
+		require.NoError(t, err, "missing test vectors (run with -update-vectors to create them)")
+		assert.Equal(t, string(exported), string(stored), "outdated test vectors in %v", path)
+	}
+}
+
+// vectorFile is the JSON form of a category of test vectors
+type vectorFile struct {
+	Version  int          `json:"version"`
+	Category string       `json:"category"`
+	Vectors  []vectorJSON `json:"vectors"`
+}
+
+// vectorJSON is the JSON form of a test vector, see testdata/vectors/README.md for the format
+type vectorJSON struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Wire       string `json:"wire"`
+	Value      any    `json:"value"`
+	DecodeOnly bool   `json:"decode_only,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// exportVectors returns the JSON form of a category of test vectors
+func exportVectors(category vectorCategory) ([]byte, error) {
+	file := vectorFile{Version: vectorsVersion, Category: category.name}
+
+	for _, vector := range category.vectors {
+		exported := vectorJSON{
+			Name:       vector.name,
+			Type:       vectorType(reflect.TypeOf(vector.value)),
+			Wire:       hex.EncodeToString(vector.wire),
+			DecodeOnly: vector.decodeOnly,
+			Error:      vector.err,
+		}
+
+		if vector.err == "" {
+			value, err := vectorValue(reflect.ValueOf(vector.value))
+			if err != nil {
+				return nil, fmt.Errorf("vector '%v': %w", vector.name, err)
+			}
+
+			exported.Value = value
+		}
+
+		file.Vectors = append(file.Vectors, exported)
+	}
+
+	// Type names are not escaped for readability
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+
+	if err := encoder.Encode(file); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+var (
+	typeRaw      = reflect.TypeOf(Raw{})
+	typeAny      = reflect.TypeOf(Any{})
+	typeDocument = reflect.TypeOf(Document{})
+	typeBigInt   = reflect.TypeOf(new(big.Int))
+)
+
+// vectorType returns the language independent name of a type for the JSON form of test vectors
+func vectorType(t reflect.Type) string {
+	switch t {
+	case typeRaw:
+		return "raw"
+	case typeAny:
+		return "any"
+	case typeDocument:
+		return "document"
+	case typeBigInt:
+		return "bigint"
+	}
+
+	switch t.Kind() {
+	case reflect.Int:
+		return "int64"
+	case reflect.Uint:
+		return "uint64"
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+
+		return fmt.Sprintf("list<%v>", vectorType(t.Elem()))
+
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("bytes<%v>", t.Len())
+		}
+
+		return fmt.Sprintf("array<%v,%v>", t.Len(), vectorType(t.Elem()))
+
+	case reflect.Map:
+		return fmt.Sprintf("map<%v,%v>", vectorType(t.Key()), vectorType(t.Elem()))
+
+	case reflect.Pointer:
+		return fmt.Sprintf("optional<%v>", vectorType(t.Elem()))
+
+	case reflect.Struct:
+		fields := make([]string, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			fields = append(fields, t.Field(i).Name+":"+vectorType(t.Field(i).Type))
+		}
+
+		return fmt.Sprintf("struct<%v>", strings.Join(fields, ","))
+
+	default:
+		return t.Kind().String()
+	}
+}
+
+// vectorValue returns the JSON form of a value for test vectors. Integers and floats are decimal strings to
+// preserve their precision, bytes and raw wires are hex strings, lists and structs are arrays and maps are
+// arrays of key-value pairs. Documents are objects of hex strings and null values are null.
+func vectorValue(value reflect.Value) (any, error) {
+	switch value.Type() {
+	case typeRaw, typeAny:
+		return hex.EncodeToString(value.Bytes()), nil
+
+	case typeDocument:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		doc := make(map[string]string, value.Len())
+		for key, raw := range value.Interface().(Document) { //nolint:forcetypeassert
+			doc[key] = hex.EncodeToString(raw)
+		}
+
+		return doc, nil
+
+	case typeBigInt:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		return value.Interface().(*big.Int).String(), nil //nolint:forcetypeassert
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), nil
+
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		return vectorValue(value.Elem())
+
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(data), value)
+
+			return hex.EncodeToString(data), nil
+		}
+
+		list := make([]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			element, err := vectorValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, element)
+		}
+
+		return list, nil
+
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		// Map entries are exported in the order of their encoded keys, as in the wire
+		type entry struct {
+			wire []byte
+			pair [2]any
+		}
+
+		entries := make([]entry, 0, value.Len())
+
+		for iter := value.MapRange(); iter.Next(); {
+			wire, err := Polorize(iter.Key().Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			key, err := vectorValue(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+
+			val, err := vectorValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, entry{wire, [2]any{key, val}})
+		}
+
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].wire, entries[j].wire) < 0 })
+
+		pairs := make([]any, 0, len(entries))
+		for _, entry := range entries {
+			pairs = append(pairs, entry.pair)
+		}
+
+		return pairs, nil
+
+	case reflect.Struct:
+		fields := make([]any, 0, value.NumField())
+
+		for i := 0; i < value.NumField(); i++ {
+			field, err := vectorValue(value.Field(i))
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, field)
+		}
+
+		return fields, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported type %v", value.Type())
+	}
+}
@@ -6,6 +6,17 @@ type wireConfig struct {
 	packBytes  bool
 	docStructs bool
 	docStrMaps bool
+	zeroCopy   bool
+
+	disallowUnknown  bool
+	caseInsensitive  bool
+	allowMissing     bool
+	disallowTrailing bool
+	schema           DocSchema
+
+	compressor      Compressor
+	decompress      bool
+	decompressLimit int
 }
 
 // defaultConfig returns a default wireConfig object
@@ -14,6 +25,17 @@ func defaultWireConfig() *wireConfig {
 		packBytes:  false,
 		docStructs: false,
 		docStrMaps: false,
+		zeroCopy:   false,
+
+		disallowUnknown:  false,
+		caseInsensitive:  false,
+		allowMissing:     false,
+		disallowTrailing: false,
+		schema:           nil,
+
+		compressor:      nil,
+		decompress:      false,
+		decompressLimit: 0,
 	}
 }
 
@@ -51,9 +73,103 @@ func DocStringMaps() EncodingOptions {
 	}
 }
 
-// inheritCfg is an EncodingOption that inherits the full config
+// ZeroCopy is an EncodingOption that sets the decoding to alias the input wire instead of
+// copying from it. Bytes, strings, Raw and Any values decoded with this option share memory
+// with the input, which must not be modified for as long as the decoded values are in use.
+// Any values are aliased when their tag is contiguous with their data, such as a top-level
+// wire or a Document value, and are copied when they are elements of a pack, whose tags
+// are stored apart from their data. It has no effect on encoding.
+func ZeroCopy() EncodingOptions {
+	return func(config *wireConfig) {
+		config.zeroCopy = true
+	}
+}
+
+// DisallowUnknownFields is an EncodingOption that sets the decoding of documents into structs
+// to fail if the document contains keys that do not match any field of the struct. Structs with
+// a field tagged as `polo:",unknown"` collect such keys instead and are unaffected by this option.
+// It has no effect on encoding.
+func DisallowUnknownFields() EncodingOptions {
+	return func(config *wireConfig) {
+		config.disallowUnknown = true
+	}
+}
+
+// CaseInsensitiveKeys is an EncodingOption that sets the decoding of documents into structs to
+// match document keys with the name or aliases of struct fields case-insensitively, if there is
+// no exact match. It has no effect on encoding, which always uses the canonical field name.
+func CaseInsensitiveKeys() EncodingOptions {
+	return func(config *wireConfig) {
+		config.caseInsensitive = true
+	}
+}
+
+// AllowMissingFields is an EncodingOption that sets the decoding of packs into structs to tolerate
+// packs with fewer elements than the struct has fields, such as those from an older writer. Missing
+// trailing fields are treated as null and retain their default values. It has no effect on encoding.
+func AllowMissingFields() EncodingOptions {
+	return func(config *wireConfig) {
+		config.allowMissing = true
+	}
+}
+
+// DisallowTrailingElements is an EncodingOption that sets the decoding of packs into structs to
+// fail if the pack has more elements than the struct has fields, such as those from a newer writer.
+// Structs with a field tagged as `polo:",rest"` collect such elements instead and are unaffected
+// by this option. It has no effect on encoding.
+func DisallowTrailingElements() EncodingOptions {
+	return func(config *wireConfig) {
+		config.disallowTrailing = true
+	}
+}
+
+// ValidateSchema is an EncodingOption that sets the decoding to validate the decoded wire against
+// a DocSchema. The wire must be a document that conforms to the schema or decoding fails with a
+// SchemaError before any object is decoded. The schema only applies to the top-level document
+// and is not inherited by nested values. It has no effect on encoding.
+func ValidateSchema(schema DocSchema) EncodingOptions {
+	return func(config *wireConfig) {
+		config.schema = schema
+	}
+}
+
+// Compression is an EncodingOption that sets the encoding to compress the wire with the given Compressor.
+// The wire is wrapped with a compression header as done by Compress. It only applies to the top-level
+// wire and is not inherited by nested values. It is ignored when encoding values into a Document or
+// an Envelope, as those are embedded into other wires. It has no effect on decoding, see Decompression.
+func Compression(compressor Compressor) EncodingOptions {
+	return func(config *wireConfig) {
+		config.compressor = compressor
+	}
+}
+
+// Decompression is an EncodingOption that sets the decoding to decompress wires that have a compression
+// header, as done by Decompress with the given size limit. Wires without a compression header are decoded
+// as is, while compressed wires fail to decode with ErrCompressedWire if this option is not used. It only
+// applies to the top-level wire and is not inherited by nested values. It has no effect on encoding, see
+// Compression.
+func Decompression(limit int) EncodingOptions {
+	return func(config *wireConfig) {
+		config.decompress = true
+		config.decompressLimit = limit
+	}
+}
+
+// uncompressed is an EncodingOption that removes any Compressor from the config. It is used for wires
+// that are embedded into other values, such as Document values and Envelope payloads, which are
+// decoded without decompression.
+func uncompressed() EncodingOptions {
+	return func(config *wireConfig) {
+		config.compressor = nil
+	}
+}
+
+// inheritCfg is an EncodingOption that inherits the full config.
+// The schema and compression of the config are not inherited as they only apply to the top-level wire.
 func inheritCfg(inherit wireConfig) EncodingOptions {
 	return func(config *wireConfig) {
 		*config = inherit
+		config.schema = nil
+		config.compressor, config.decompress = nil, false
 	}
 }